@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/winartodev/apollo-be/internal/domain"
+	"github.com/winartodev/apollo-be/modules/auth"
+)
+
+func apiKeyCommand(args []string) error {
+	return subcommand("apikey", args, map[string]command{
+		"create": {usage: "NAME, issue a key for the X-API-Key header, it is only printed once", run: apiKeyCreate},
+		"revoke": {usage: "ID, revoke a key", run: apiKeyRevoke},
+	})
+}
+
+func apiKeyCreate(args []string) error {
+	positional, err := parseArgs(newFlagSet("apikey create", "NAME"), args, 1)
+	if err != nil {
+		return err
+	}
+
+	return withAPIKeyService(func(ctx context.Context, apiKeyService domain.APIKeyService) error {
+		key, apiKey, err := apiKeyService.CreateAPIKey(ctx, positional[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("id: %d\nname: %s\nkey: %s\n", apiKey.ID, apiKey.Name, key)
+		return nil
+	})
+}
+
+func apiKeyRevoke(args []string) error {
+	flags := newFlagSet("apikey revoke", "ID")
+
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		flags.Usage()
+		return errUsage
+	}
+
+	return withAPIKeyService(func(ctx context.Context, apiKeyService domain.APIKeyService) error {
+		if err := apiKeyService.RevokeAPIKey(ctx, id); err != nil {
+			return err
+		}
+
+		fmt.Printf("revoked api key %d\n", id)
+		return nil
+	})
+}
+
+func withAPIKeyService(fn func(ctx context.Context, apiKeyService domain.APIKeyService) error) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	db, redis, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnections(db, redis)
+
+	apiKeyService, err := auth.InitializeAPIKeyService(db, redis)
+	if err != nil {
+		return err
+	}
+
+	return fn(context.Background(), apiKeyService)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/modules/audit"
+)
+
+func auditCommand(args []string) error {
+	return subcommand("audit", args, map[string]command{
+		"prune": {usage: "delete the audit events older than audit.retention", run: auditPrune},
+	})
+}
+
+func auditPrune(args []string) error {
+	if _, err := parseArgs(newFlagSet("audit prune", ""), args, 0); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	db, redis, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnections(db, redis)
+
+	auditLog, err := audit.InitializeAuditUseCase(db, &cfg.Audit, logger.NewLogger(&cfg.Logging, cfg.Environment))
+	if err != nil {
+		return err
+	}
+
+	deleted, err := auditLog.PruneEvents(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d audit events older than %s deleted\n", deleted, cfg.Audit.GetRetention())
+	return nil
+}
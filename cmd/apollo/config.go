@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	config2 "github.com/winartodev/apollo-be/config"
+)
+
+func configCommand(args []string) error {
+	return subcommand("config", args, map[string]command{
+		"validate": {usage: "load the configuration and report every missing or invalid field", run: configValidate},
+	})
+}
+
+func configValidate(args []string) error {
+	if _, err := parseArgs(newFlagSet("config validate", ""), args, 0); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		var validationError *config2.ValidationError
+		if errors.As(err, &validationError) {
+			fmt.Fprintln(os.Stderr, validationError.Error())
+			return errUsage
+		}
+
+		return err
+	}
+
+	fmt.Printf("configuration for %s is valid\n", cfg.Environment)
+	return nil
+}
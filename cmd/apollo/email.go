@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/winartodev/apollo-be/infrastructure/smtp"
+)
+
+func emailCommand(args []string) error {
+	return subcommand("email", args, map[string]command{
+		"list":    {usage: "list the email templates and the data they expect", run: emailList},
+		"preview": {usage: "NAME, render a template with sample data", run: emailPreview},
+	})
+}
+
+func emailList(args []string) error {
+	if _, err := parseArgs(newFlagSet("email list", ""), args, 0); err != nil {
+		return err
+	}
+
+	templates, err := newTemplateService()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range templates.Templates() {
+		data, _ := json.Marshal(templates.Sample(name))
+		fmt.Printf("%-20s %s\n", name, data)
+	}
+
+	return nil
+}
+
+func emailPreview(args []string) error {
+	flags := newFlagSet("email preview", "NAME")
+	locale := flags.String("locale", smtp.DefaultLocale, "locale or Accept-Language header to render with")
+	format := flags.String("format", "html", "html or text")
+	dataFile := flags.String("data", "", "JSON file with the template data, the sample data when omitted")
+
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	if *format != "html" && *format != "text" {
+		flags.Usage()
+		return errUsage
+	}
+
+	templates, err := newTemplateService()
+	if err != nil {
+		return err
+	}
+
+	data := templates.Sample(positional[0])
+	if *dataFile != "" {
+		content, err := os.ReadFile(*dataFile)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(content, &data); err != nil {
+			return fmt.Errorf("invalid template data: %w", err)
+		}
+	}
+
+	email, err := templates.Render(positional[0], templates.ResolveLocale(*locale), data)
+	if err != nil {
+		return err
+	}
+
+	if *format == "text" {
+		fmt.Printf("Subject: %s\n\n%s", email.Subject, email.TextBody)
+		return nil
+	}
+
+	fmt.Print(email.HTMLBody)
+	return nil
+}
+
+// newTemplateService reads the templates from the configured override directory, so designers can preview their changes
+func newTemplateService() (smtp.TemplateService, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return smtp.NewTemplateService(&cfg.SMTP), nil
+}
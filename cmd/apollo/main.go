@@ -0,0 +1,161 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/redis/go-redis/v9"
+	config2 "github.com/winartodev/apollo-be/config"
+	_ "github.com/winartodev/apollo-be/docs"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"serve":   {usage: "run the HTTP server", run: serve},
+	"migrate": {usage: "up | down N | goto V | force V | status | create NAME", run: migrate},
+	"user":    {usage: "create | deactivate | reset-password", run: userCommand},
+	"apikey":  {usage: "create | revoke", run: apiKeyCommand},
+	"outbox":  {usage: "status | retry", run: outboxCommand},
+	"audit":   {usage: "prune", run: auditCommand},
+	"email":   {usage: "list | preview", run: emailCommand},
+	"config":  {usage: "validate", run: configCommand},
+	"secrets": {usage: "keygen | encrypt | decrypt", run: secretsCommand},
+}
+
+// errUsage is returned when the arguments are wrong, the usage has already been printed
+var errUsage = errors.New("invalid usage")
+
+// @title			Apollo API
+// @version		1.0
+// @description	This is the Apollo server.
+// @host			localhost:8081
+// @BasePath		/api
+// @security		Definitions.apikey BearerAuth
+// @in				header
+// @name			Authorization
+// @schemes		http https
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage: apollo <command> [arguments]\n\nCommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", name, commands[name].usage)
+	}
+}
+
+// subcommand runs the handler registered for args[0]
+func subcommand(name string, args []string, handlers map[string]command) error {
+	if len(args) > 0 {
+		if handler, ok := handlers[args[0]]; ok {
+			return handler.run(args[1:])
+		}
+	}
+
+	names := make([]string, 0, len(handlers))
+	for sub := range handlers {
+		names = append(names, sub)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "Usage: apollo %s <command> [arguments]\n\nCommands:\n", name)
+	for _, sub := range names {
+		fmt.Fprintf(os.Stderr, "  %-15s %s\n", sub, handlers[sub].usage)
+	}
+
+	return errUsage
+}
+
+// newFlagSet returns a flag set printing "apollo <name> [flags] <arguments>" as usage
+func newFlagSet(name string, arguments string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: apollo %s [flags] %s\n", name, arguments)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+// parseArgs parses the flags and returns exactly nargs positional arguments
+func parseArgs(flags *flag.FlagSet, args []string, nargs int) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() != nargs {
+		flags.Usage()
+		return nil, errUsage
+	}
+
+	return flags.Args(), nil
+}
+
+// loadConfig loads the configuration and installs the configured logger as the default one
+func loadConfig() (*config2.Config, error) {
+	cfg, err := config2.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.NewLogger(&cfg.Logging, cfg.Environment).SetDefault()
+
+	return cfg, nil
+}
+
+// connect opens the database and Redis connections shared by the commands
+func connect(cfg *config2.Config) (*sql.DB, *redis.Client, error) {
+	db, err := cfg.Database.SetupConnection()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redisClient, err := cfg.Redis.SetupConnection()
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+
+	return db, redisClient, nil
+}
+
+// closeConnections closes connections opened by connect
+func closeConnections(db *sql.DB, redisClient *redis.Client) {
+	_ = db.Close()
+	_ = redisClient.Close()
+}
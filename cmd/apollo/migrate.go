@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	config2 "github.com/winartodev/apollo-be/config"
+)
+
+func migrate(args []string) error {
+	return subcommand("migrate", args, map[string]command{
+		"up":     {usage: "apply all pending migrations, -dry-run only lists them", run: migrateUp},
+		"down":   {usage: "N, roll back the last N migrations", run: migrateDown},
+		"goto":   {usage: "V, migrate up or down to version V", run: migrateGoto},
+		"force":  {usage: "V, set the version without running migrations, e.g. after fixing a dirty state", run: migrateForce},
+		"status": {usage: "print the current version, dirty state and pending migrations", run: migrateStatus},
+		"create": {usage: "NAME, create empty up and down migration files", run: migrateCreate},
+	})
+}
+
+func migrateUp(args []string) error {
+	flags := newFlagSet("migrate up", "")
+	dryRun := flags.Bool("dry-run", false, "list pending migrations without applying them")
+
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	return withMigration(func(autoMigration *config2.AutoMigration) error {
+		if *dryRun {
+			return printPending(autoMigration)
+		}
+
+		return autoMigration.Start()
+	})
+}
+
+func migrateDown(args []string) error {
+	n, err := parseNumberArg("migrate down", "N", args)
+	if err != nil {
+		return err
+	}
+
+	if n <= 0 {
+		return fmt.Errorf("N must be greater than 0")
+	}
+
+	return withMigration(func(autoMigration *config2.AutoMigration) error {
+		return autoMigration.Steps(-n)
+	})
+}
+
+func migrateGoto(args []string) error {
+	version, err := parseNumberArg("migrate goto", "V", args)
+	if err != nil {
+		return err
+	}
+
+	if version < 0 {
+		return fmt.Errorf("V must not be negative")
+	}
+
+	return withMigration(func(autoMigration *config2.AutoMigration) error {
+		return autoMigration.Goto(uint(version))
+	})
+}
+
+func migrateForce(args []string) error {
+	version, err := parseNumberArg("migrate force", "V", args)
+	if err != nil {
+		return err
+	}
+
+	return withMigration(func(autoMigration *config2.AutoMigration) error {
+		return autoMigration.Force(version)
+	})
+}
+
+func migrateStatus(args []string) error {
+	if _, err := parseArgs(newFlagSet("migrate status", ""), args, 0); err != nil {
+		return err
+	}
+
+	return withMigration(func(autoMigration *config2.AutoMigration) error {
+		version, dirty, err := autoMigration.Status()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("version: %d\ndirty: %v\n", version, dirty)
+		return printPending(autoMigration)
+	})
+}
+
+func printPending(autoMigration *config2.AutoMigration) error {
+	pending, err := autoMigration.Pending()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("pending: %d\n", len(pending))
+	for _, migration := range pending {
+		fmt.Printf("  %d %s\n", migration.Version, migration.Name)
+	}
+
+	return nil
+}
+
+func migrateCreate(args []string) error {
+	positional, err := parseArgs(newFlagSet("migrate create", "NAME"), args, 1)
+	if err != nil {
+		return err
+	}
+
+	files, err := config2.CreateMigration(positional[0])
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		fmt.Println(file)
+	}
+
+	return nil
+}
+
+// withMigration connects to the database only, migrations don't need Redis
+func withMigration(fn func(autoMigration *config2.AutoMigration) error) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	return runMigration(cfg, fn)
+}
+
+// migrateOnBoot applies pending migrations for serve -migrate, replicas wait on the advisory lock in turn
+func migrateOnBoot(cfg *config2.Config) error {
+	return runMigration(cfg, func(autoMigration *config2.AutoMigration) error {
+		return autoMigration.Start()
+	})
+}
+
+// runMigration opens its own connection because closing the migration closes the database handle too
+func runMigration(cfg *config2.Config, fn func(autoMigration *config2.AutoMigration) error) error {
+	db, err := cfg.Database.SetupConnection()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	autoMigration, err := config2.NewAutoMigration(cfg.Database.Name, db)
+	if err != nil {
+		return err
+	}
+
+	defer autoMigration.Close()
+
+	return fn(autoMigration)
+}
+
+func parseNumberArg(name string, argument string, args []string) (int, error) {
+	flags := newFlagSet(name, argument)
+
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(positional[0])
+	if err != nil {
+		flags.Usage()
+		return 0, errUsage
+	}
+
+	return n, nil
+}
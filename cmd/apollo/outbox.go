@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	config2 "github.com/winartodev/apollo-be/config"
+	infraDatabase "github.com/winartodev/apollo-be/infrastructure/database"
+	"github.com/winartodev/apollo-be/infrastructure/outbox"
+)
+
+const deadJobsShown = 20
+
+func outboxCommand(args []string) error {
+	return subcommand("outbox", args, map[string]command{
+		"status": {usage: "count the jobs by status and show the latest dead-lettered ones", run: outboxStatus},
+		"retry":  {usage: "ID | -all, run dead-lettered jobs again", run: outboxRetry},
+		"prune":  {usage: "delete the dead-lettered jobs older than outbox.deadRetention", run: outboxPrune},
+	})
+}
+
+func outboxStatus(args []string) error {
+	if _, err := parseArgs(newFlagSet("outbox status", ""), args, 0); err != nil {
+		return err
+	}
+
+	return withOutbox(func(ctx context.Context, _ *config2.Config, jobs *outbox.Outbox) error {
+		stats, err := jobs.Stats(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("pending: %d\nrunning: %d\ndead: %d\n", stats[outbox.StatusPending], stats[outbox.StatusRunning], stats[outbox.StatusDead])
+
+		dead, err := jobs.DeadJobs(ctx, deadJobsShown)
+		if err != nil {
+			return err
+		}
+
+		for _, job := range dead {
+			fmt.Printf("\n#%d %s, %d attempts, failed %s\n  %s\n", job.ID, job.Kind, job.Attempts, job.FailedAt.UTC().Format(time.RFC3339), job.LastError)
+		}
+
+		return nil
+	})
+}
+
+func outboxRetry(args []string) error {
+	flags := newFlagSet("outbox retry", "ID | -all")
+	all := flags.Bool("all", false, "retry every dead-lettered job")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var id int64
+	switch {
+	case *all && flags.NArg() == 0:
+	case !*all && flags.NArg() == 1:
+		parsed, err := strconv.ParseInt(flags.Arg(0), 10, 64)
+		if err != nil || parsed <= 0 {
+			flags.Usage()
+			return errUsage
+		}
+
+		id = parsed
+	default:
+		flags.Usage()
+		return errUsage
+	}
+
+	return withOutbox(func(ctx context.Context, _ *config2.Config, jobs *outbox.Outbox) error {
+		count, err := jobs.RetryDead(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if id != 0 && count == 0 {
+			return fmt.Errorf("no dead-lettered job %d", id)
+		}
+
+		fmt.Printf("%d jobs queued again\n", count)
+		return nil
+	})
+}
+
+func outboxPrune(args []string) error {
+	if _, err := parseArgs(newFlagSet("outbox prune", ""), args, 0); err != nil {
+		return err
+	}
+
+	return withOutbox(func(ctx context.Context, cfg *config2.Config, jobs *outbox.Outbox) error {
+		deleted, err := jobs.PruneDead(ctx, time.Now().Add(-cfg.Outbox.GetDeadRetention()))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%d dead jobs deleted\n", deleted)
+		return nil
+	})
+}
+
+func withOutbox(fn func(ctx context.Context, cfg *config2.Config, jobs *outbox.Outbox) error) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	db, redis, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnections(db, redis)
+
+	database, err := infraDatabase.NewDatabase(db)
+	if err != nil {
+		return err
+	}
+
+	return fn(context.Background(), cfg, outbox.NewOutbox(database))
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	config2 "github.com/winartodev/apollo-be/config"
+)
+
+const envSecretsKey = "APOLLO_SECRETS_FILE_KEY"
+
+// secretsCommand manages the encrypted secrets file, secrets.json is a flat JSON object such as
+// {"database/password": "..."} referenced in the configuration as secret:database/password
+func secretsCommand(args []string) error {
+	return subcommand("secrets", args, map[string]command{
+		"keygen":  {usage: "print a new key for APOLLO_SECRETS_FILE_KEY", run: secretsKeygen},
+		"encrypt": {usage: "-in secrets.json -out secrets.enc, encrypt with APOLLO_SECRETS_FILE_KEY", run: secretsEncrypt},
+		"decrypt": {usage: "-in secrets.enc, print the secrets decrypted with APOLLO_SECRETS_FILE_KEY", run: secretsDecrypt},
+	})
+}
+
+func secretsKeygen(args []string) error {
+	if _, err := parseArgs(newFlagSet("secrets keygen", ""), args, 0); err != nil {
+		return err
+	}
+
+	key, err := config2.GenerateSecretsKey()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(key)
+
+	return nil
+}
+
+func secretsEncrypt(args []string) error {
+	flags := newFlagSet("secrets encrypt", "")
+	in := flags.String("in", "-", "plain JSON secrets, - for stdin")
+	out := flags.String("out", "", "encrypted secrets file (required)")
+
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	if *out == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	key, err := config2.DecodeSecretsKey(os.Getenv(envSecretsKey))
+	if err != nil {
+		return err
+	}
+
+	plain, err := readInput(*in)
+	if err != nil {
+		return err
+	}
+
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return fmt.Errorf("secrets must be a JSON object of strings: %w", err)
+	}
+
+	data, err := config2.EncryptSecrets(key, secrets)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(*out, data, 0o600)
+}
+
+func secretsDecrypt(args []string) error {
+	flags := newFlagSet("secrets decrypt", "")
+	in := flags.String("in", "", "encrypted secrets file (required)")
+
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	if *in == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	key, err := config2.DecodeSecretsKey(os.Getenv(envSecretsKey))
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := config2.DecryptSecrets(key, data)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(secrets)
+}
+
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(path)
+}
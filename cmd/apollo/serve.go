@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	redisClient "github.com/redis/go-redis/v9"
+	echoSwagger "github.com/swaggo/echo-swagger"
+	config2 "github.com/winartodev/apollo-be/config"
+	infraAuth "github.com/winartodev/apollo-be/infrastructure/auth"
+	infraDatabase "github.com/winartodev/apollo-be/infrastructure/database"
+	"github.com/winartodev/apollo-be/infrastructure/events"
+	"github.com/winartodev/apollo-be/infrastructure/health"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/infrastructure/metrics"
+	middleware2 "github.com/winartodev/apollo-be/infrastructure/middleware"
+	"github.com/winartodev/apollo-be/infrastructure/outbox"
+	"github.com/winartodev/apollo-be/infrastructure/phone"
+	redisInfra "github.com/winartodev/apollo-be/infrastructure/redis"
+	"github.com/winartodev/apollo-be/infrastructure/routes"
+	"github.com/winartodev/apollo-be/infrastructure/smtp"
+	"github.com/winartodev/apollo-be/infrastructure/tracing"
+	"github.com/winartodev/apollo-be/modules/audit"
+	"github.com/winartodev/apollo-be/modules/auth"
+	"github.com/winartodev/apollo-be/modules/country"
+	countryUseCase "github.com/winartodev/apollo-be/modules/country/usecase"
+	"github.com/winartodev/apollo-be/modules/user"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+// serve runs the HTTP server until SIGINT or SIGTERM
+func serve(args []string) error {
+	flags := newFlagSet("serve", "")
+	runMigrations := flags.Bool("migrate", false, "apply pending migrations before serving, safe to use on every replica")
+
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if *runMigrations {
+		if err := migrateOnBoot(cfg); err != nil {
+			return err
+		}
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), &cfg.Tracing, cfg.App.Name)
+	if err != nil {
+		return err
+	}
+
+	db, redis, err := connect(cfg)
+	if err != nil {
+		_ = shutdownTracing(context.Background())
+		return err
+	}
+
+	appLogger := logger.NewLogger(&cfg.Logging, cfg.Environment)
+	serverLogger := appLogger.Named("server")
+
+	e := echo.New()
+
+	e.HideBanner = true
+	e.HidePort = true
+	// X-Forwarded-For is only trusted from loopback and private network proxies, so clients can't spoof their IP
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
+
+	countries, err := country.InitializeCountryUseCase(redis, &cfg.Country, appLogger)
+	if err != nil {
+		return err
+	}
+
+	validate := validator.New()
+	if err := phone.RegisterValidations(validate, phone.NewPhoneNumberService(&cfg.Phone), countryRegionResolver(countries)); err != nil {
+		return err
+	}
+
+	e.Validator = &config2.CustomValidator{
+		Validator: validate,
+	}
+
+	appMetrics := metrics.NewMetrics(db, cfg.Database.Name, redis)
+	configWatcher := config2.NewWatcher(cfg)
+
+	rateLimiter, err := newRateLimiter(cfg, redis, configWatcher, appLogger)
+	if err != nil {
+		return err
+	}
+
+	e.Use(middleware2.RequestID())
+	e.Use(otelecho.Middleware(tracing.ServiceName(cfg.App.Name)))
+	e.Use(appMetrics.Middleware())
+	e.Use(appLogger.Middleware())
+	e.Use(appLogger.Recover())
+	e.Use(rateLimiter.Middleware())
+	e.Use(middleware.Secure())
+	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+		Level: 5, // Compression level
+	}))
+	e.Use(middleware2.GetAppPlatform())
+	e.Use(middleware2.AcceptLanguage())
+	e.Use(middleware2.ClientInfo())
+
+	e.GET("/swagger/*", echoSwagger.WrapHandler)
+
+	if cfg.Storage.Driver == "" || cfg.Storage.Driver == config2.StorageDriverLocal {
+		e.Static(cfg.Storage.Local.GetBaseURL(), cfg.Storage.Local.GetDirectory())
+	}
+
+	eventBus, err := newEventBus(cfg, db, redis, configWatcher, appLogger)
+	if err != nil {
+		return err
+	}
+
+	authHandler, err := auth.InitializeAuthAPI(db, redis, &cfg.Jwt, &cfg.SMTP, configWatcher, &cfg.Username, &cfg.Country, &cfg.Phone, appMetrics, appLogger, eventBus)
+	if err != nil {
+		return err
+	}
+
+	otpHandler, err := auth.InitializeOtpAPI(db, redis, &cfg.Jwt, &cfg.SMTP, configWatcher, appMetrics, appLogger, eventBus)
+	if err != nil {
+		return err
+	}
+
+	invitationHandler, err := auth.InitializeInvitationAPI(db, redis, &cfg.Jwt, &cfg.SMTP, configWatcher)
+	if err != nil {
+		return err
+	}
+
+	userHandler, err := user.InitializeUserAPI(db, redis, &cfg.Jwt, &cfg.Storage, configWatcher)
+	if err != nil {
+		return err
+	}
+
+	countryHandler, err := country.InitializeCountryAPI(redis, &cfg.Country, appLogger)
+	if err != nil {
+		return err
+	}
+
+	auditHandler, err := audit.InitializeAuditAPI(db, redis, &cfg.Jwt, &cfg.Audit, appLogger)
+	if err != nil {
+		return err
+	}
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go configWatcher.Start(jobCtx)
+
+	mailer := smtp.NewMailer(&cfg.SMTP)
+	// The mailbox shows every email, including OTPs, to anyone who can reach it
+	if mailbox, ok := mailer.(*smtp.Mailbox); ok {
+		if cfg.IsDevelopment() {
+			serverLogger.Warn("emails are kept in the dev mailbox instead of being sent, see /dev/mail")
+			mailbox.RegisterRoutes(e)
+		} else {
+			serverLogger.Warn("emails are kept in the dev mailbox instead of being sent, /dev/mail is only served in development")
+		}
+	}
+
+	outboxWorker, err := newOutboxWorker(cfg, db, mailer, appLogger)
+	if err != nil {
+		return err
+	}
+
+	go outboxWorker.Start(jobCtx)
+
+	auditPruneJob, err := audit.InitializeAuditPruneJob(db, &cfg.Audit, appLogger)
+	if err != nil {
+		return err
+	}
+
+	go auditPruneJob.Start(jobCtx)
+
+	if cfg.Country.Refresh.IsEnabled() {
+		countryRefreshJob, err := country.InitializeCountryRefreshJob(redis, &cfg.Country, appLogger)
+		if err != nil {
+			return err
+		}
+
+		go countryRefreshJob.Start(jobCtx)
+	}
+
+	healthChecker := newHealthChecker(cfg, db, redis)
+	healthChecker.RegisterRoutes(e)
+
+	if err := routes.RegisterHandler(e, authHandler, userHandler, otpHandler, invitationHandler, countryHandler, auditHandler); err != nil {
+		return err
+	}
+
+	shutdownChan := make(chan struct{})
+
+	go func() {
+		serverLogger.Info("http server started", "port", cfg.Http.Port)
+		if err := e.Start(fmt.Sprintf("0.0.0.0:%v", cfg.Http.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverLogger.Error("http server stopped", "error", err)
+			close(shutdownChan)
+		}
+	}()
+
+	adminServer := newAdminServer(cfg, appMetrics)
+	if adminServer != nil {
+		go func() {
+			serverLogger.Info("admin server started", "addr", adminServer.Addr)
+			if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverLogger.Error("admin server stopped", "error", err)
+			}
+		}()
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case <-quit:
+		serverLogger.Info("received shutdown signal")
+	case <-shutdownChan:
+		serverLogger.Error("server crashed, initiating shutdown")
+	}
+
+	healthChecker.SetShuttingDown()
+	if delay := cfg.Health.ShutdownDelay.Duration(); delay > 0 {
+		serverLogger.Info("waiting for load balancers to drain", "delay", delay)
+		time.Sleep(delay)
+	}
+
+	stopJobs()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	serverLogger.Info("shutting down server")
+	if err := e.Shutdown(ctx); err != nil {
+		serverLogger.Error("http server shutdown failed", "error", err)
+	}
+
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			serverLogger.Error("admin server shutdown failed", "error", err)
+		}
+	}
+
+	serverLogger.Info("draining outbox jobs")
+	if err := outboxWorker.Drain(ctx); err != nil {
+		serverLogger.Error("outbox drain failed", "error", err)
+	}
+
+	if err := mailer.Close(); err != nil {
+		serverLogger.Error("mailer close failed", "error", err)
+	}
+
+	serverLogger.Info("closing database connections")
+	if db != nil {
+		if err := db.Close(); err != nil {
+			serverLogger.Error("database close failed", "error", err)
+		}
+	}
+
+	serverLogger.Info("closing Redis connection")
+	if redis != nil {
+		if err := redis.Close(); err != nil {
+			serverLogger.Error("Redis close failed", "error", err)
+		}
+	}
+
+	serverLogger.Info("flushing traces")
+	if err := shutdownTracing(ctx); err != nil {
+		serverLogger.Error("tracing shutdown failed", "error", err)
+	}
+
+	serverLogger.Info("server exited properly")
+
+	return nil
+}
+
+// newRateLimiter reads the access token only to key requests by user, so it needs the JWT service
+func newRateLimiter(cfg *config2.Config, redis *redisClient.Client, configWatcher *config2.Watcher, appLogger *logger.Logger) (*middleware2.RateLimiter, error) {
+	redisWrapper, err := redisInfra.NewRedis(redis)
+	if err != nil {
+		return nil, err
+	}
+
+	jwt, err := infraAuth.NewJWT(&cfg.Jwt)
+	if err != nil {
+		return nil, err
+	}
+
+	return middleware2.NewRateLimiter(redisWrapper, infraAuth.NewJwtTokenService(jwt), configWatcher, appLogger), nil
+}
+
+// newOutboxWorker delivers queued emails with the mailer
+func newOutboxWorker(cfg *config2.Config, db *sql.DB, mailer smtp.Mailer, appLogger *logger.Logger) (*outbox.Worker, error) {
+	database, err := infraDatabase.NewDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+
+	worker := outbox.NewWorker(outbox.NewOutbox(database), &cfg.Outbox, appLogger)
+	worker.Handle(smtp.EmailJobKind, smtp.NewEmailJobHandler(mailer))
+
+	return worker, nil
+}
+
+// newEventBus records account events in the audit log and publishes them to the security notifier
+func newEventBus(cfg *config2.Config, db *sql.DB, redis *redisClient.Client, configWatcher *config2.Watcher, appLogger *logger.Logger) (*events.Bus, error) {
+	auditLog, err := audit.InitializeAuditUseCase(db, &cfg.Audit, appLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier, err := auth.InitializeSecurityNotifier(db, redis, &cfg.SMTP, configWatcher, appLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	bus := events.NewBus()
+	bus.Subscribe(auditLog.Handle)
+	bus.Subscribe(notifier.Handle)
+
+	return bus, nil
+}
+
+// countryRegionResolver lets the phone validator accept the alpha-3 and numeric codes the country data knows
+func countryRegionResolver(countries countryUseCase.CountryUseCase) phone.RegionResolver {
+	return func(ctx context.Context, code string) (string, error) {
+		country, err := countries.GetCountry(ctx, code, "")
+		if err != nil {
+			return "", err
+		}
+
+		return country.Alpha2, nil
+	}
+}
+
+// newHealthChecker checks the database and Redis, and the SMTP server when enabled and used
+func newHealthChecker(cfg *config2.Config, db *sql.DB, redis *redisClient.Client) *health.Checker {
+	checks := []health.Check{
+		health.DatabaseCheck(db),
+		health.RedisCheck(redis),
+	}
+
+	if cfg.Health.CheckSMTP && cfg.SMTP.GetTransport() == config2.SMTPTransportSMTP {
+		checks = append(checks, health.SMTPCheck(&cfg.SMTP))
+	}
+
+	return health.NewChecker(&cfg.Health, checks...)
+}
+
+// newAdminServer serves /metrics on the admin port, which should not be exposed publicly
+func newAdminServer(cfg *config2.Config, appMetrics *metrics.Metrics) *http.Server {
+	if cfg.Http.AdminPort == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", appMetrics.Handler())
+
+	return &http.Server{
+		Addr:              fmt.Sprintf("0.0.0.0:%v", cfg.Http.AdminPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
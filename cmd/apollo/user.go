@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-playground/validator"
+	config2 "github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/modules/auth"
+	"github.com/winartodev/apollo-be/modules/auth/usecase"
+	"github.com/winartodev/apollo-be/modules/auth/usecase/dto"
+)
+
+// userInput mirrors the sign-up rules for users created from the command line
+type userInput struct {
+	Username string `validate:"required,min=3,max=30"`
+	Email    string `validate:"required,email"`
+	passwordInput
+}
+
+type passwordInput struct {
+	Password string `validate:"required,min=8,max=100"`
+}
+
+func userCommand(args []string) error {
+	return subcommand("user", args, map[string]command{
+		"create":         {usage: "create a user, skipping invitations and OTP verification", run: userCreate},
+		"deactivate":     {usage: "USER, block a user by username or email from signing in", run: userDeactivate},
+		"reset-password": {usage: "USER, set a new password and sign the user out", run: userResetPassword},
+	})
+}
+
+func userCreate(args []string) error {
+	flags := newFlagSet("user create", "")
+	data := dto.CreateUserDto{}
+	flags.StringVar(&data.Username, "username", "", "username (required)")
+	flags.StringVar(&data.Email, "email", "", "email address (required)")
+	flags.StringVar(&data.Password, "password", "", "password, read from stdin when omitted")
+	flags.StringVar(&data.PhoneNumber, "phone", "", "phone number")
+	flags.StringVar(&data.FirstName, "first-name", "", "first name")
+	flags.StringVar(&data.LastName, "last-name", "", "last name")
+	flags.BoolVar(&data.IsAdmin, "admin", false, "grant admin rights")
+
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	password, err := readPassword(data.Password)
+	if err != nil {
+		return err
+	}
+
+	data.Password = password
+	if err := validateInput(userInput{Username: data.Username, Email: data.Email, passwordInput: passwordInput{Password: data.Password}}); err != nil {
+		return err
+	}
+
+	return withUserAdmin(func(ctx context.Context, userAdmin usecase.UserAdminUseCase) error {
+		user, err := userAdmin.CreateUser(ctx, data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("created user %d (%s, %s), admin: %v\n", user.ID, user.Username, user.Email, user.IsAdmin)
+		return nil
+	})
+}
+
+func userDeactivate(args []string) error {
+	positional, err := parseArgs(newFlagSet("user deactivate", "USER"), args, 1)
+	if err != nil {
+		return err
+	}
+
+	return withUserAdmin(func(ctx context.Context, userAdmin usecase.UserAdminUseCase) error {
+		if err := userAdmin.DeactivateUser(ctx, positional[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("deactivated %s\n", positional[0])
+		return nil
+	})
+}
+
+func userResetPassword(args []string) error {
+	flags := newFlagSet("user reset-password", "USER")
+	password := flags.String("password", "", "new password, read from stdin when omitted")
+
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	newPassword, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	if err := validateInput(passwordInput{Password: newPassword}); err != nil {
+		return err
+	}
+
+	return withUserAdmin(func(ctx context.Context, userAdmin usecase.UserAdminUseCase) error {
+		if err := userAdmin.ResetPassword(ctx, positional[0], newPassword); err != nil {
+			return err
+		}
+
+		fmt.Printf("password reset for %s\n", positional[0])
+		return nil
+	})
+}
+
+func withUserAdmin(fn func(ctx context.Context, userAdmin usecase.UserAdminUseCase) error) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	db, redis, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnections(db, redis)
+
+	appLogger := logger.NewLogger(&cfg.Logging, cfg.Environment)
+	eventBus, err := newEventBus(cfg, db, redis, config2.NewWatcher(cfg), appLogger)
+	if err != nil {
+		return err
+	}
+
+	userAdmin, err := auth.InitializeUserAdmin(db, redis, &cfg.Phone, eventBus)
+	if err != nil {
+		return err
+	}
+
+	return fn(context.Background(), userAdmin)
+}
+
+// readPassword returns the flag value or the first line of stdin, so passwords stay out of the shell history
+func readPassword(value string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+
+	fmt.Fprint(os.Stderr, "password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("password is required")
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func validateInput(input interface{}) error {
+	err := validator.New().Struct(input)
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	problems := make([]string, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		problems = append(problems, fmt.Sprintf("%s: %s", strings.ToLower(fe.Field()), describeInputError(fe)))
+	}
+
+	return errors.New(strings.Join(problems, "\n"))
+}
+
+func describeInputError(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return "must be at least " + fe.Param() + " characters"
+	case "max":
+		return "must be at most " + fe.Param() + " characters"
+	case "email":
+		return "must be a valid email address"
+	default:
+		return "failed the " + fe.Tag() + " check"
+	}
+}
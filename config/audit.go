@@ -0,0 +1,46 @@
+package config
+
+import "time"
+
+const (
+	defaultAuditRetention      = 90 * 24 * time.Hour
+	defaultAuditPruneInterval  = time.Hour
+	defaultAuditPruneBatchSize = 1000
+)
+
+// Audit configures the audit log of authentication and account events
+type Audit struct {
+	// Retention is how long events are kept, defaults to 90 days
+	Retention Duration `yaml:"retention" validate:"min=0"`
+	// PruneInterval is how often events older than Retention are deleted, defaults to 1h
+	PruneInterval Duration `yaml:"pruneInterval" validate:"min=0"`
+	// PruneBatchSize limits the rows deleted by one statement, so pruning doesn't hold long locks. Defaults to 1000
+	PruneBatchSize int `yaml:"pruneBatchSize" validate:"min=0"`
+}
+
+// GetRetention returns the configured retention or the default one
+func (a *Audit) GetRetention() time.Duration {
+	if a.Retention <= 0 {
+		return defaultAuditRetention
+	}
+
+	return a.Retention.Duration()
+}
+
+// GetPruneInterval returns the configured prune interval or the default one
+func (a *Audit) GetPruneInterval() time.Duration {
+	if a.PruneInterval <= 0 {
+		return defaultAuditPruneInterval
+	}
+
+	return a.PruneInterval.Duration()
+}
+
+// GetPruneBatchSize returns the configured prune batch size or the default one
+func (a *Audit) GetPruneBatchSize() int {
+	if a.PruneBatchSize <= 0 {
+		return defaultAuditPruneBatchSize
+	}
+
+	return a.PruneBatchSize
+}
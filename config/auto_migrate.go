@@ -1,126 +1,314 @@
 package config
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	pg "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"github.com/winartodev/apollo-be/helper"
+	"github.com/winartodev/apollo-be/migrations"
 )
 
 const (
-	migrationsDir = "migrations"
-	osWindows     = "windows"
+	migrationsDir          = "migrations"
+	migrationVersionFormat = "20060102150405"
+
+	// migrationLockName keeps the runner lock apart from the per statement lock taken by golang-migrate
+	migrationLockName         = "apollo_migration_runner"
+	migrationLockTimeout      = 5 * time.Minute
+	migrationLockPollInterval = time.Second
 )
 
+// ErrDirtyDatabase is returned when a previous migration failed half way, the schema has to be fixed by hand
+var ErrDirtyDatabase = errors.New("database is dirty")
+
+var migrationNameCleaner = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
 type SchemaMigration struct {
 	version int
 	dirty   bool
 }
 
+// PendingMigration is a migration that has not been applied yet
+type PendingMigration struct {
+	Version uint
+	Name    string
+}
+
+// AutoMigration runs the migrations and takes the runner lock on one dedicated connection,
+// so it works with a pool limited to a single open connection
 type AutoMigration struct {
-	migrate *migrate.Migrate
+	migrate      *migrate.Migrate
+	db           *sql.DB
+	conn         *sql.Conn
+	databaseName string
 }
 
 func NewAutoMigration(databaseName string, db *sql.DB) (*AutoMigration, error) {
-	sourceURL, err := generateSourceURL()
+	src, err := newMigrationSource()
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf("failed to generate source URL: %v", err))
+		return nil, err
 	}
 
-	x, err := pg.WithInstance(db, &pg.Config{})
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
 	if err != nil {
+		_ = src.Close()
+		return nil, errors.New(fmt.Sprintf("failed to get a connection for migrations: %v", err))
+	}
+
+	x, err := pg.WithConnection(ctx, conn, &pg.Config{})
+	if err != nil {
+		_ = conn.Close()
+		_ = src.Close()
 		return nil, errors.New(fmt.Sprintf("failed to create database driver instance: %v", err))
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(*sourceURL, databaseName, x)
+	m, err := migrate.NewWithInstance("iofs", src, databaseName, x)
 	if err != nil {
+		_ = x.Close()
+		_ = src.Close()
 		return nil, errors.New(fmt.Sprintf("failed to get schema version after migration: %v", err))
 	}
 
-	log.Printf("AutoMigration initialized successfully for database: %s", databaseName)
+	configLogger().Info("migrations initialized", "database", databaseName)
 	return &AutoMigration{
-		migrate: m,
+		migrate:      m,
+		db:           db,
+		conn:         conn,
+		databaseName: databaseName,
 	}, nil
 }
 
+// Start applies all pending migrations. It refuses to run on a dirty database and never rolls back on failure,
+// the failed migration is left dirty for an operator to inspect
 func (am *AutoMigration) Start() error {
-	schemaUpErr := am.migrate.Up()
-	if schemaUpErr == nil {
+	return am.withLock(func() error {
+		if err := am.checkClean(); err != nil {
+			return err
+		}
+
+		schemaUpErr := am.migrate.Up()
+		if isErrorNoChange(schemaUpErr) {
+			configLogger().Info("no migrations to run, the database is up to date")
+			return nil
+		}
+
 		schema, err := am.getSchemaMigration()
 		if err != nil {
-			return errors.New(fmt.Sprintf("failed to get schema version after migration error: %v", err))
+			return errors.New(fmt.Sprintf("failed to get schema version after migration: %v", err))
 		}
 
-		log.Printf("Migration successful. Current schema version: %d", schema.version)
-		return nil
-	}
+		if schemaUpErr != nil {
+			configLogger().Error("migration failed", "error", schemaUpErr, "version", schema.version, "dirty", schema.dirty)
+			return fmt.Errorf("migration failed at version %d: %w", schema.version, schemaUpErr)
+		}
 
-	if isErrorNoChange(schemaUpErr) {
-		log.Printf("No migrations to run. Database is already at the latest version.")
+		configLogger().Info("migrations applied", "version", schema.version)
 		return nil
-	}
+	})
+}
 
-	// Handle if migrate failed
+// Force sets the version and clears the dirty flag without running any migration
+func (am *AutoMigration) Force(version int) error {
+	return am.withLock(func() error {
+		configLogger().Warn("forcing schema version", "version", version)
+
+		err := am.migrate.Force(version)
+		if err != nil {
+			return errors.New(fmt.Sprintf("failed to force schema version: %v", err))
+		}
+
+		return am.logStatus()
+	})
+}
+
+// Steps applies n migrations up, or rolls back n migrations when n is negative
+func (am *AutoMigration) Steps(n int) error {
+	return am.withLock(func() error {
+		if err := am.checkClean(); err != nil {
+			return err
+		}
+
+		err := am.migrate.Steps(n)
+		if err != nil && !isErrorNoChange(err) {
+			return errors.New(fmt.Sprintf("failed to migrate %d steps: %v", n, err))
+		}
+
+		return am.logStatus()
+	})
+}
+
+// Goto migrates up or down to the given version
+func (am *AutoMigration) Goto(version uint) error {
+	return am.withLock(func() error {
+		if err := am.checkClean(); err != nil {
+			return err
+		}
+
+		err := am.migrate.Migrate(version)
+		if err != nil && !isErrorNoChange(err) {
+			return errors.New(fmt.Sprintf("failed to migrate to version %d: %v", version, err))
+		}
+
+		return am.logStatus()
+	})
+}
+
+// Status returns the current schema version and whether the last migration failed half way
+func (am *AutoMigration) Status() (version int, dirty bool, err error) {
 	schema, err := am.getSchemaMigration()
 	if err != nil {
-		return errors.New(fmt.Sprintf("failed to get schema version after migration error: %v", err))
+		return 0, false, err
 	}
 
-	log.Printf("Migration failed error {%v}.\nCurrent schema version: %d, dirty: %v", schemaUpErr.Error(), schema.version, schema.dirty)
+	return schema.version, schema.dirty, nil
+}
+
+// Pending lists the migrations Start would apply, in order
+func (am *AutoMigration) Pending() ([]PendingMigration, error) {
+	version, _, versionErr := am.migrate.Version()
+	if isNonNilAndNotExpectedMigrationError(versionErr) {
+		return nil, errors.New(fmt.Sprintf("failed to retrieve schema version: %v", versionErr))
+	}
 
-	err = am.Fix(schema.version)
+	src, err := newMigrationSource()
 	if err != nil {
-		return errors.New(fmt.Sprintf("failed to fix schema version: %v", err))
+		return nil, err
 	}
 
-	err = am.Rollback()
+	defer src.Close()
+
+	var next uint
+	if isErrorNoMigration(versionErr) {
+		next, err = src.First()
+	} else {
+		next, err = src.Next(version)
+	}
+
+	var pending []PendingMigration
+	for err == nil {
+		name, readErr := readMigrationName(src, next)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		pending = append(pending, PendingMigration{Version: next, Name: name})
+		next, err = src.Next(next)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New(fmt.Sprintf("failed to read migrations: %v", err))
+	}
+
+	return pending, nil
+}
+
+// Close releases the migration source and closes the database handle given to NewAutoMigration
+func (am *AutoMigration) Close() error {
+	sourceErr, databaseErr := am.migrate.Close()
+	return errors.Join(sourceErr, databaseErr, am.db.Close())
+}
+
+func (am *AutoMigration) checkClean() error {
+	schema, err := am.getSchemaMigration()
 	if err != nil {
-		return errors.New(fmt.Sprintf("failed to rollback migration: %v", err))
+		return err
 	}
 
-	log.Printf("Migration recovery completed successfully.")
+	if schema.dirty {
+		return fmt.Errorf("%w at version %d, fix the schema by hand then run apollo migrate force <version>", ErrDirtyDatabase, schema.version)
+	}
 
 	return nil
 }
 
-func (am *AutoMigration) Fix(version int) error {
-	log.Printf("Fixing schema version to: %d", version)
+// withLock runs fn while holding a Postgres advisory lock, so replicas migrating on boot run one after another.
+// The lock is session scoped, it is taken on the connection the migrations run on so no second connection is needed
+func (am *AutoMigration) withLock(fn func() error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), migrationLockTimeout)
+	defer cancel()
 
-	err := am.migrate.Force(version)
+	lockID, err := database.GenerateAdvisoryLockId(am.databaseName, migrationLockName)
 	if err != nil {
-		return errors.New(fmt.Sprintf("failed to force schema version: %v", err))
+		return err
 	}
 
+	conn := am.conn
+	for {
+		var locked bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockID).Scan(&locked); err != nil {
+			return errors.New(fmt.Sprintf("failed to acquire the migration lock: %v", err))
+		}
+
+		if locked {
+			break
+		}
+
+		configLogger().Info("waiting for another instance to finish migrating")
+		select {
+		case <-ctx.Done():
+			return errors.New(fmt.Sprintf("timed out after %s waiting for the migration lock", migrationLockTimeout))
+		case <-time.After(migrationLockPollInterval):
+		}
+	}
+
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID); err != nil {
+			configLogger().Error("failed to release the migration lock", "error", err)
+		}
+	}()
+
+	return fn()
+}
+
+func (am *AutoMigration) logStatus() error {
 	schema, err := am.getSchemaMigration()
 	if err != nil {
-		return errors.New(fmt.Sprintf("failed to get schema version after fix: %v", err))
+		return err
 	}
 
-	log.Printf("Schema fix successful. Current version: %d, dirty: %v", schema.version, schema.dirty)
+	configLogger().Info("schema status", "version", schema.version, "dirty", schema.dirty)
 	return nil
 }
 
-func (am *AutoMigration) Rollback() error {
-	log.Printf("Rolling back schema by 1 step.")
-
-	err := am.migrate.Steps(-1)
-	if err != nil {
-		return errors.New(fmt.Sprintf("failed to rollback schema: %v", err))
+// CreateMigration writes empty up and down files named <timestamp>_<name> to the migrations directory of the
+// source tree, they are embedded on the next build
+func CreateMigration(name string) (files []string, err error) {
+	name = strings.ToLower(migrationNameCleaner.ReplaceAllString(strings.TrimSpace(name), "_"))
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return nil, errors.New("migration name is required")
 	}
 
-	schema, err := am.getSchemaMigration()
+	dir, err := helper.GetCompletePath(migrationsDir)
 	if err != nil {
-		return errors.New(fmt.Sprintf("failed to get schema version after rollback: %v", err))
+		return nil, err
 	}
 
-	log.Printf("Rollback successful. Current version: %d, dirty: %v", schema.version, schema.dirty)
+	version := time.Now().UTC().Format(migrationVersionFormat)
+	for _, direction := range []string{"up", "down"} {
+		file := filepath.Join(dir, fmt.Sprintf("%s_%s.%s.sql", version, name, direction))
+		if err := os.WriteFile(file, nil, 0o644); err != nil {
+			return nil, err
+		}
 
-	return nil
+		files = append(files, file)
+	}
+
+	return files, nil
 }
 
 func (am *AutoMigration) getSchemaMigration() (*SchemaMigration, error) {
@@ -135,24 +323,29 @@ func (am *AutoMigration) getSchemaMigration() (*SchemaMigration, error) {
 	}, nil
 }
 
-func generateSourceURL() (*string, error) {
-	filePath, err := helper.GetCompletePath(migrationsDir)
+func readMigrationName(src source.Driver, version uint) (string, error) {
+	body, identifier, err := src.ReadUp(version)
 	if err != nil {
-		return nil, errors.New(err.Error())
+		return "", errors.New(fmt.Sprintf("failed to read migration %d: %v", version, err))
 	}
 
-	var completePath string
-	if helper.CurrentOS(osWindows) {
-		completePath = fmt.Sprintf("file:%s", filePath)
-	} else {
-		completePath = fmt.Sprintf("file://%s", filePath)
+	_ = body.Close()
+
+	return identifier, nil
+}
+
+// newMigrationSource reads the migrations embedded in the binary
+func newMigrationSource() (source.Driver, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to open migrations: %v", err))
 	}
 
-	return &completePath, nil
+	return src, nil
 }
 
 func isNonNilAndNotExpectedMigrationError(err error) bool {
-	return err != nil && isErrorNoMigration(err) && isErrorNoChange(err)
+	return err != nil && !isErrorNoMigration(err) && !isErrorNoChange(err)
 }
 
 func isErrorNoChange(err error) bool {
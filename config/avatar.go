@@ -0,0 +1,19 @@
+package config
+
+const (
+	defaultAvatarMaxSize = 5 << 20 // 5 MB
+)
+
+// Avatar holds user avatar upload configuration
+type Avatar struct {
+	MaxSize int64 `yaml:"maxSize"` // in bytes
+}
+
+// GetMaxSize returns the configured max upload size or the default one
+func (a *Avatar) GetMaxSize() int64 {
+	if a.MaxSize <= 0 {
+		return defaultAvatarMaxSize
+	}
+
+	return a.MaxSize
+}
@@ -9,45 +9,137 @@ import (
 )
 
 const (
-	developmentConfigPath = "files/apollo.development.yaml"
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+
+	envName       = "APOLLO_ENV"
+	envConfigPath = "APOLLO_CONFIG_PATH"
+
+	configPathFormat = "files/apollo.%s.yaml"
 
 	errorLoadConfig = "error while loading config file %v"
 )
 
+// Config is the application configuration. Sections tagged reload:"restart" are only read at start up,
+// the others are picked up by Watcher without a restart.
 type Config struct {
+	// Environment is selected by APOLLO_ENV and defaults to development
+	Environment string `yaml:"-"`
+
 	App struct {
 		Name string `yaml:"name"`
-	} `yaml:"app"`
+	} `yaml:"app" reload:"restart"`
 
 	Http struct {
-		Port string `yaml:"port"`
-	}
+		Port string `yaml:"port" validate:"required"`
+		// AdminPort serves /metrics apart from the public API, it is disabled when empty
+		AdminPort string `yaml:"adminPort"`
+	} `reload:"restart"`
 
-	Database Database `yaml:"database"`
+	Database Database `yaml:"database" reload:"restart"`
 
-	Jwt Jwt `yaml:"jwt"`
+	Jwt Jwt `yaml:"jwt" reload:"restart"`
 
-	Redis RedisConfig `yaml:"redis"`
+	Redis RedisConfig `yaml:"redis" reload:"restart"`
 
-	SMTP SMTPConfig `yaml:"smtp"`
+	SMTP SMTPConfig `yaml:"smtp" reload:"restart"`
 
 	OTP Otp `yaml:"otp"`
+
+	Username Username `yaml:"username" reload:"restart"`
+
+	Storage Storage `yaml:"storage" reload:"restart"`
+
+	Avatar Avatar `yaml:"avatar"`
+
+	Registration Registration `yaml:"registration"`
+
+	SecurityNotifications SecurityNotifications `yaml:"securityNotifications"`
+
+	Country Country `yaml:"country" reload:"restart"`
+
+	Phone Phone `yaml:"phone" reload:"restart"`
+
+	Features Features `yaml:"features"`
+
+	RateLimit RateLimit `yaml:"rateLimit"`
+
+	Secrets Secrets `yaml:"secrets" reload:"restart"`
+
+	Tracing Tracing `yaml:"tracing" reload:"restart"`
+
+	Logging Logging `yaml:"logging" reload:"restart"`
+
+	Health Health `yaml:"health" reload:"restart"`
+
+	Outbox Outbox `yaml:"outbox" reload:"restart"`
+
+	Audit Audit `yaml:"audit" reload:"restart"`
 }
 
+// LoadConfig reads files/apollo.<APOLLO_ENV>.yaml, or the file in APOLLO_CONFIG_PATH, then applies the
+// APOLLO_* environment variables on top of it. The file is optional when everything comes from the environment.
+// Every invalid or missing field is reported at once in a *ValidationError.
 func LoadConfig() (*Config, error) {
-	var cfg Config
-	err := helper.ReadYaml(developmentConfigPath, &cfg)
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf(errorLoadConfig, err))
+	cfg := Config{
+		Environment: GetEnvironment(),
 	}
 
-	if err = os.Setenv(JwtAccessTokenSecretKey, cfg.Jwt.AccessTokenSecret); err != nil {
-		return nil, err
+	path, explicit := configPath(cfg.Environment)
+	if _, err := os.Stat(path); err == nil || explicit {
+		if err := helper.ReadYaml(path, &cfg); err != nil {
+			return nil, errors.New(fmt.Sprintf(errorLoadConfig, err))
+		}
 	}
 
-	if err = os.Setenv(JwtRefreshTokenSecretKey, cfg.Jwt.RefreshTokenSecret); err != nil {
-		return nil, err
+	fields := collectFields(&cfg)
+	problems := applyEnvOverrides(fields)
+	problems = append(problems, validateConfig(&cfg, fields)...)
+	problems = append(problems, validateSecretReferences(&cfg, fields)...)
+	problems = append(problems, validateProduction(&cfg)...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
 	}
 
+	secretsProvider, err := NewSecretsProvider(&cfg.Secrets)
+	if err != nil {
+		return nil, &ValidationError{Problems: []string{fmt.Sprintf("secrets: %v", err)}}
+	}
+
+	cfg.attachSecrets(secretsProvider)
+
 	return &cfg, nil
 }
+
+// configPath returns the configuration file and whether it was set explicitly with APOLLO_CONFIG_PATH
+func configPath(environment string) (path string, explicit bool) {
+	if path := os.Getenv(envConfigPath); path != "" {
+		return path, true
+	}
+
+	return fmt.Sprintf(configPathFormat, environment), false
+}
+
+// GetEnvironment returns the environment selected by APOLLO_ENV
+func GetEnvironment() string {
+	if env := os.Getenv(envName); env != "" {
+		return env
+	}
+
+	return EnvDevelopment
+}
+
+// IsProduction reports whether the configuration was loaded for production
+func (c *Config) IsProduction() bool {
+	return c.Environment == EnvProduction
+}
+
+// IsDevelopment reports whether the configuration was loaded for development
+func (c *Config) IsDevelopment() bool {
+	return c.Environment == EnvDevelopment
+}
+
+// IsFeatureEnabled reports whether the feature flag is enabled
+func (c *Config) IsFeatureEnabled(name string) bool {
+	return c.Features.IsEnabled(name)
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"time"
+)
+
+const (
+	defaultCountryRefreshInterval = 24 * time.Hour
+	defaultCountryRefreshTimeout  = 10 * time.Second
+)
+
+// Country holds country dataset configuration
+type Country struct {
+	Refresh CountryRefresh `yaml:"refresh"`
+}
+
+// CountryRefresh holds configuration of the optional job syncing the country dataset from an upstream source.
+// Countries are always served from memory, the embedded dataset is used until a synced copy is available in Redis.
+type CountryRefresh struct {
+	Enabled bool `yaml:"enabled"`
+
+	// UpstreamURL must serve the dataset in the same JSON format as the embedded one
+	UpstreamURL string `yaml:"upstreamURL" validate:"omitempty,url"`
+
+	Interval Duration `yaml:"interval" validate:"min=0"`
+	Timeout  Duration `yaml:"timeout" validate:"min=0"`
+}
+
+// IsEnabled reports whether the refresh job should run
+func (c *CountryRefresh) IsEnabled() bool {
+	return c.Enabled && c.UpstreamURL != ""
+}
+
+// GetInterval returns the configured refresh interval or the default one
+func (c *CountryRefresh) GetInterval() time.Duration {
+	if c.Interval <= 0 {
+		return defaultCountryRefreshInterval
+	}
+
+	return c.Interval.Duration()
+}
+
+// GetTimeout returns the configured upstream request timeout or the default one
+func (c *CountryRefresh) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return defaultCountryRefreshTimeout
+	}
+
+	return c.Timeout.Duration()
+}
@@ -3,15 +3,19 @@ package config
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
-	"time"
+	"net"
+	"net/url"
 
-	_ "github.com/lib/pq"
+	"github.com/XSAM/otelsql"
+	"github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 const (
-	postgresDNS = "postgres://%s:%s@%s:%s/%s?sslmode=%s"
+	postgresScheme = "postgres"
 
 	errorOpenDBConnection = "error open db connection %v"
 	errorPingDB           = "error ping db %v"
@@ -19,45 +23,98 @@ const (
 )
 
 type Database struct {
-	Driver          string `yaml:"driver"`
-	Host            string `yaml:"host"`
-	Port            string `yaml:"port"`
-	Name            string `yaml:"name"`
-	Username        string `yaml:"username"`
-	Password        string `yaml:"password"`
-	SSLMode         string `yaml:"sslMode"`
-	MaxOpenConn     int    `yaml:"defaultMaxConn"`
-	MaxIdleConn     int    `yaml:"defaultIdleConn"`
-	ConnMaxLifetime int    `yaml:"connMaxLifetime"`
-	ConnMaxIdleTime int    `yaml:"connMaxIdleTime"`
+	Driver          string   `yaml:"driver" validate:"required"`
+	Host            string   `yaml:"host" validate:"required"`
+	Port            string   `yaml:"port" validate:"required"`
+	Name            string   `yaml:"name" validate:"required"`
+	Username        string   `yaml:"username" validate:"required"`
+	Password        Secret   `yaml:"password"`
+	SSLMode         string   `yaml:"sslMode" validate:"omitempty,oneof=disable allow prefer require verify-ca verify-full"`
+	MaxOpenConn     int      `yaml:"defaultMaxConn" validate:"min=0"`
+	MaxIdleConn     int      `yaml:"defaultIdleConn" validate:"min=0"`
+	ConnMaxLifetime Duration `yaml:"connMaxLifetime" validate:"min=0"`
+	ConnMaxIdleTime Duration `yaml:"connMaxIdleTime" validate:"min=0"`
+
+	secrets SecretsProvider
 }
 
-func (d *Database) SetupConnection() (*sql.DB, error) {
-	dsn := fmt.Sprintf(postgresDNS, d.Username, d.Password, d.Host, d.Port, d.Name, d.SSLMode)
-	db, err := sql.Open(d.Driver, dsn)
+// GetPassword returns the current database password
+func (d *Database) GetPassword(ctx context.Context) (string, error) {
+	return resolveSecret(ctx, d.secrets, d.Password)
+}
+
+// GetDSN returns the connection string with the current password
+func (d *Database) GetDSN(ctx context.Context) (string, error) {
+	password, err := d.GetPassword(ctx)
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf(errorOpenDBConnection, err))
+		return "", err
 	}
 
-	db.SetConnMaxLifetime(time.Duration(d.ConnMaxLifetime) * time.Second)
+	dsn := url.URL{
+		Scheme: postgresScheme,
+		User:   url.UserPassword(d.Username, password),
+		Host:   net.JoinHostPort(d.Host, d.Port),
+		Path:   d.Name,
+	}
+
+	if d.SSLMode != "" {
+		dsn.RawQuery = url.Values{"sslmode": {d.SSLMode}}.Encode()
+	}
+
+	return dsn.String(), nil
+}
+
+func (d *Database) SetupConnection() (*sql.DB, error) {
+	// The connector builds the DSN for every new connection so a rotated password is used without a restart.
+	// Queries are traced with the global tracer provider, they are no-ops until tracing is set up.
+	db := otelsql.OpenDB(&databaseConnector{database: d},
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBNamespace(d.Name)),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
+
+	db.SetConnMaxLifetime(d.ConnMaxLifetime.Duration())
+	db.SetConnMaxIdleTime(d.ConnMaxIdleTime.Duration())
 	db.SetMaxIdleConns(d.MaxIdleConn)
 	db.SetMaxOpenConns(d.MaxOpenConn)
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(d.ConnMaxLifetime)*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), d.ConnMaxLifetime.Duration())
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
 		return nil, errors.New(fmt.Sprintf(errorPingDB, err))
 	}
 
-	if err := validateConnectionPool(ctx, db); err != nil {
+	if err := ValidateConnectionPool(ctx, db); err != nil {
 		return nil, errors.New(fmt.Sprintf(errorValidateConnDB, err))
 	}
 
 	return db, nil
 }
 
-func validateConnectionPool(ctx context.Context, db *sql.DB) error {
+type databaseConnector struct {
+	database *Database
+}
+
+func (dc *databaseConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := dc.database.GetDSN(ctx)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf(errorOpenDBConnection, err))
+	}
+
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf(errorOpenDBConnection, err))
+	}
+
+	return connector.Connect(ctx)
+}
+
+func (dc *databaseConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// ValidateConnectionPool checks out a pooled connection and runs a query on it
+func ValidateConnectionPool(ctx context.Context, db *sql.DB) error {
 	conn, err := db.Conn(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration is a time.Duration read from values like "90s" or "15m".
+// Bare integers are read as seconds so older config files keep working.
+type Duration time.Duration
+
+// Duration returns the value as a time.Duration
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// Seconds returns the value in whole seconds
+func (d Duration) Seconds() int64 {
+	return int64(time.Duration(d) / time.Second)
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return d.UnmarshalText([]byte(value.Value))
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	value := strings.TrimSpace(string(text))
+	if value == "" {
+		*d = 0
+		return nil
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		*d = Duration(time.Duration(seconds) * time.Second)
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", value)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	envPrefix     = "APOLLO"
+	envFileSuffix = "_FILE"
+)
+
+// configField describes a leaf field of the configuration
+type configField struct {
+	value     reflect.Value
+	namespace string // Go namespace as reported by the validator, e.g. Config.Database.Host
+	path      string // yaml path, e.g. database.host
+	env       string // environment variable, e.g. APOLLO_DATABASE_HOST
+}
+
+// collectFields walks the configuration and returns every leaf field
+func collectFields(cfg *Config) []configField {
+	var fields []configField
+	walkFields(reflect.ValueOf(cfg).Elem(), "Config", "", envPrefix, &fields)
+
+	return fields
+}
+
+func walkFields(v reflect.Value, namespace string, path string, env string, fields *[]configField) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := yamlKey(field)
+		if key == "-" {
+			continue
+		}
+
+		fieldNamespace := namespace + "." + field.Name
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		fieldEnv := env + "_" + toEnvName(key)
+		fieldValue := v.Field(i)
+
+		if fieldValue.Kind() == reflect.Struct && !isTextUnmarshaler(fieldValue) {
+			walkFields(fieldValue, fieldNamespace, fieldPath, fieldEnv, fields)
+			continue
+		}
+
+		*fields = append(*fields, configField{
+			value:     fieldValue,
+			namespace: fieldNamespace,
+			path:      fieldPath,
+			env:       fieldEnv,
+		})
+	}
+}
+
+// applyEnvOverrides sets every field that has an APOLLO_* environment variable.
+// A variable with the _FILE suffix is read from the file it points to, e.g. Docker or Kubernetes secrets.
+func applyEnvOverrides(fields []configField) (problems []string) {
+	for _, field := range fields {
+		value, ok, err := lookupEnv(field.env)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s (%s): %v", field.path, field.env+envFileSuffix, err))
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := setFieldValue(field.value, value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (%s): %v", field.path, field.env, err))
+		}
+	}
+
+	return problems
+}
+
+func lookupEnv(name string) (value string, ok bool, err error) {
+	if path, ok := os.LookupEnv(name + envFileSuffix); ok && path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, err
+		}
+
+		return strings.TrimRight(string(data), "\r\n"), true, nil
+	}
+
+	value, ok = os.LookupEnv(name)
+	return value, ok, nil
+}
+
+func setFieldValue(field reflect.Value, value string) error {
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+
+		field.SetInt(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+
+		field.SetFloat(parsed)
+	case reflect.Pointer:
+		// Optional fields are pointers so an explicit zero differs from unset
+		elem := reflect.New(field.Type().Elem())
+		if err := setFieldValue(elem.Elem(), value); err != nil {
+			return err
+		}
+
+		field.Set(elem)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+
+		// Slices are comma separated, e.g. APOLLO_REGISTRATION_ALLOWED_DOMAINS=example.com,example.org
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
+
+func isTextUnmarshaler(v reflect.Value) bool {
+	_, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+	return ok
+}
+
+// yamlKey returns the key yaml.v3 uses for the field, the lower cased field name when there is no tag
+func yamlKey(field reflect.StructField) string {
+	tag := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if tag == "" {
+		return strings.ToLower(field.Name)
+	}
+
+	return tag
+}
+
+// toEnvName converts a camel case key to upper snake case, e.g. accessTokenSecret -> ACCESS_TOKEN_SECRET
+// and upstreamURL -> UPSTREAM_URL
+func toEnvName(key string) string {
+	runes := []rune(key)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FeatureInvitations lets users create invitations, codes that were already issued keep working when it is disabled
+const FeatureInvitations = "invitations"
+
+// featureDefaults holds the flags that are enabled unless they are set to false
+var featureDefaults = map[string]bool{
+	FeatureInvitations: true,
+}
+
+// Features holds feature flags by name, e.g. features: {invitations: false}
+type Features map[string]bool
+
+// IsEnabled reports whether the flag is enabled, unset flags use their default and unknown flags are disabled
+func (f Features) IsEnabled(name string) bool {
+	if enabled, ok := f[name]; ok {
+		return enabled
+	}
+
+	return featureDefaults[name]
+}
+
+// UnmarshalText parses flags from an environment variable, e.g. APOLLO_FEATURES=invitations=false.
+// A name without a value is enabled.
+func (f *Features) UnmarshalText(text []byte) error {
+	features := make(Features)
+	for _, item := range strings.Split(string(text), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(item, "=")
+		if !found {
+			features[name] = true
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid feature flag %q", item)
+		}
+
+		features[strings.TrimSpace(name)] = enabled
+	}
+
+	*f = features
+
+	return nil
+}
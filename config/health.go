@@ -0,0 +1,36 @@
+package config
+
+import "time"
+
+const (
+	defaultHealthTimeout  = 2 * time.Second
+	defaultHealthCacheTTL = time.Second
+)
+
+// Health configures the /readyz dependency checks
+type Health struct {
+	Timeout  Duration `yaml:"timeout" validate:"min=0"`  // per check, defaults to 2s
+	CacheTTL Duration `yaml:"cacheTTL" validate:"min=0"` // how long a result is reused, defaults to 1s
+	// CheckSMTP also dials the SMTP server, off by default because mail outages shouldn't take the API out of rotation
+	CheckSMTP bool `yaml:"checkSMTP"`
+	// ShutdownDelay keeps serving while /readyz reports shutting down, so load balancers stop routing first
+	ShutdownDelay Duration `yaml:"shutdownDelay" validate:"min=0"`
+}
+
+// GetTimeout returns the configured check timeout or the default one
+func (h *Health) GetTimeout() time.Duration {
+	if h.Timeout <= 0 {
+		return defaultHealthTimeout
+	}
+
+	return h.Timeout.Duration()
+}
+
+// GetCacheTTL returns the configured result cache TTL or the default one
+func (h *Health) GetCacheTTL() time.Duration {
+	if h.CacheTTL <= 0 {
+		return defaultHealthCacheTTL
+	}
+
+	return h.CacheTTL.Duration()
+}
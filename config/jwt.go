@@ -1,11 +1,20 @@
 package config
 
-const (
-	JwtAccessTokenSecretKey  = "JWT_ACCESS_TOKEN_SECRET_KEY"
-	JwtRefreshTokenSecretKey = "JWT_REFRESH_TOKEN_SECRET_KEY"
-)
+import "context"
 
 type Jwt struct {
-	AccessTokenSecret  string `yaml:"accessTokenSecret"`
-	RefreshTokenSecret string `yaml:"refreshTokenSecret"`
+	AccessTokenSecret  Secret `yaml:"accessTokenSecret" validate:"required"`
+	RefreshTokenSecret Secret `yaml:"refreshTokenSecret" validate:"required"`
+
+	secrets SecretsProvider
+}
+
+// GetAccessTokenSecret returns the current access token signing key
+func (j *Jwt) GetAccessTokenSecret(ctx context.Context) (string, error) {
+	return resolveSecret(ctx, j.secrets, j.AccessTokenSecret)
+}
+
+// GetRefreshTokenSecret returns the current refresh token signing key
+func (j *Jwt) GetRefreshTokenSecret(ctx context.Context) (string, error) {
+	return resolveSecret(ctx, j.secrets, j.RefreshTokenSecret)
 }
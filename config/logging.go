@@ -0,0 +1,40 @@
+package config
+
+import "log/slog"
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+
+	// LogPackageKey is the attribute that selects the per-package level
+	LogPackageKey = "package"
+)
+
+// Logging configures the application logger
+type Logging struct {
+	// Level is debug, info, warn or error, defaults to info
+	Level string `yaml:"level" validate:"omitempty,oneof=debug info warn error"`
+	// Format is json or text, defaults to json in production and text otherwise
+	Format string `yaml:"format" validate:"omitempty,oneof=json text"`
+	// Packages overrides the level per package, e.g. {config: warn, auth: debug}
+	Packages map[string]string `yaml:"packages" validate:"dive,oneof=debug info warn error"`
+}
+
+// GetFormat returns the configured format or the default one for the environment
+func (l *Logging) GetFormat(environment string) string {
+	if l.Format != "" {
+		return l.Format
+	}
+
+	if environment == EnvProduction {
+		return LogFormatJSON
+	}
+
+	return LogFormatText
+}
+
+// configLogger is used by this package, which loads before the application logger exists.
+// It goes through slog.Default, so the output follows the logger installed by the command.
+func configLogger() *slog.Logger {
+	return slog.Default().With(LogPackageKey, "config")
+}
@@ -1,7 +1,32 @@
 package config
 
+import "time"
+
+const (
+	defaultOtpExpiration  = 3 * time.Minute
+	defaultOtpMaxAttempts = 3
+)
+
 type Otp struct {
-	Expiration    int64 `yaml:"expiration"`
-	MaxAttempt    int64 `yaml:"maxAttempts"`
-	RetryInterval int64 `yaml:"retryInterval"`
+	Expiration    Duration `yaml:"expiration" validate:"min=0"`
+	MaxAttempt    int64    `yaml:"maxAttempts" validate:"min=0"`
+	RetryInterval Duration `yaml:"retryInterval" validate:"min=0"`
+}
+
+// GetExpiration returns how long a code is valid, or the default when it isn't configured
+func (o *Otp) GetExpiration() time.Duration {
+	if o.Expiration <= 0 {
+		return defaultOtpExpiration
+	}
+
+	return o.Expiration.Duration()
+}
+
+// GetMaxAttempts returns how many codes can be sent before the user has to wait, or the default
+func (o *Otp) GetMaxAttempts() int64 {
+	if o.MaxAttempt <= 0 {
+		return defaultOtpMaxAttempts
+	}
+
+	return o.MaxAttempt
 }
@@ -0,0 +1,92 @@
+package config
+
+import "time"
+
+const (
+	defaultOutboxWorkers       = 4
+	defaultOutboxPollInterval  = time.Second
+	defaultOutboxMaxAttempts   = 8
+	defaultOutboxMinBackoff    = 10 * time.Second
+	defaultOutboxMaxBackoff    = time.Hour
+	defaultOutboxLease         = 5 * time.Minute
+	defaultOutboxDeadRetention = 72 * time.Hour
+)
+
+// Outbox configures the background job worker that delivers the outbox, e.g. emails
+type Outbox struct {
+	Workers      int      `yaml:"workers" validate:"min=0"`      // jobs run at the same time, defaults to 4
+	PollInterval Duration `yaml:"pollInterval" validate:"min=0"` // defaults to 1s
+	// MaxAttempts is how often a job runs before it is dead-lettered, defaults to 8
+	MaxAttempts int `yaml:"maxAttempts" validate:"min=0"`
+	// MinBackoff doubles after every failed attempt up to MaxBackoff, defaults to 10s and 1h
+	MinBackoff Duration `yaml:"minBackoff" validate:"min=0"`
+	MaxBackoff Duration `yaml:"maxBackoff" validate:"min=0"`
+	// Lease is how long a claimed job is reserved, a job still running after that is retried. Defaults to 5m
+	Lease Duration `yaml:"lease" validate:"min=0"`
+	// DeadRetention is how long dead-lettered jobs are kept for `apollo outbox retry`. Their payloads hold
+	// rendered emails with one-time codes, so they are deleted afterwards. Defaults to 72h
+	DeadRetention Duration `yaml:"deadRetention" validate:"min=0"`
+}
+
+// GetWorkers returns the configured worker count or the default one
+func (o *Outbox) GetWorkers() int {
+	if o.Workers <= 0 {
+		return defaultOutboxWorkers
+	}
+
+	return o.Workers
+}
+
+// GetPollInterval returns the configured poll interval or the default one
+func (o *Outbox) GetPollInterval() time.Duration {
+	if o.PollInterval <= 0 {
+		return defaultOutboxPollInterval
+	}
+
+	return o.PollInterval.Duration()
+}
+
+// GetMaxAttempts returns the configured attempt limit or the default one
+func (o *Outbox) GetMaxAttempts() int {
+	if o.MaxAttempts <= 0 {
+		return defaultOutboxMaxAttempts
+	}
+
+	return o.MaxAttempts
+}
+
+// GetMinBackoff returns the configured first retry delay or the default one
+func (o *Outbox) GetMinBackoff() time.Duration {
+	if o.MinBackoff <= 0 {
+		return defaultOutboxMinBackoff
+	}
+
+	return o.MinBackoff.Duration()
+}
+
+// GetMaxBackoff returns the configured retry delay limit or the default one
+func (o *Outbox) GetMaxBackoff() time.Duration {
+	if o.MaxBackoff <= 0 {
+		return defaultOutboxMaxBackoff
+	}
+
+	return o.MaxBackoff.Duration()
+}
+
+// GetLease returns the configured job lease or the default one
+func (o *Outbox) GetLease() time.Duration {
+	if o.Lease <= 0 {
+		return defaultOutboxLease
+	}
+
+	return o.Lease.Duration()
+}
+
+// GetDeadRetention returns the configured dead job retention or the default one
+func (o *Outbox) GetDeadRetention() time.Duration {
+	if o.DeadRetention <= 0 {
+		return defaultOutboxDeadRetention
+	}
+
+	return o.DeadRetention.Duration()
+}
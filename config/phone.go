@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+)
+
+const (
+	PhoneTypeMobile            = "mobile"
+	PhoneTypeFixedLine         = "fixed_line"
+	PhoneTypeFixedLineOrMobile = "fixed_line_or_mobile"
+	PhoneTypeVoip              = "voip"
+
+	defaultPhoneRegion = "ID"
+)
+
+var defaultPhoneTypes = []string{
+	PhoneTypeMobile,
+	PhoneTypeFixedLineOrMobile,
+}
+
+// Phone holds phone number parsing and validation configuration
+type Phone struct {
+	// DefaultRegion is the ISO 3166-1 alpha-2 region used for numbers without a country calling code
+	DefaultRegion string `yaml:"defaultRegion" validate:"omitempty,len=2"`
+
+	// AllowedTypes lists the accepted number types, e.g. [mobile, fixed_line_or_mobile]
+	AllowedTypes []string `yaml:"allowedTypes" validate:"dive,oneof=mobile fixed_line fixed_line_or_mobile voip"`
+}
+
+// GetDefaultRegion returns the configured default region or the default one
+func (p *Phone) GetDefaultRegion() string {
+	if p.DefaultRegion == "" {
+		return defaultPhoneRegion
+	}
+
+	return strings.ToUpper(p.DefaultRegion)
+}
+
+// GetAllowedTypes returns the configured number types or the default ones
+func (p *Phone) GetAllowedTypes() []string {
+	if len(p.AllowedTypes) == 0 {
+		return defaultPhoneTypes
+	}
+
+	return p.AllowedTypes
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	RateLimitSlidingWindow = "sliding_window"
+	RateLimitTokenBucket   = "token_bucket"
+
+	RateLimitKeyIP     = "ip"
+	RateLimitKeyUser   = "user"
+	RateLimitKeyAPIKey = "api_key"
+	RateLimitKeyRoute  = "route"
+)
+
+// RateLimit configures the Redis backed rate limiter. Routes are keyed by method and route template,
+// e.g. "POST /api/auth/sign-in", and replace the built-in policy for that route.
+type RateLimit struct {
+	Disabled bool                       `yaml:"disabled"`
+	Default  RateLimitPolicy            `yaml:"default"` // applies to routes without a policy, unlimited when empty
+	Routes   map[string]RateLimitPolicy `yaml:"routes" validate:"dive"`
+}
+
+// RateLimitPolicy allows Limit requests per Window for each key. With the token bucket algorithm Limit is the
+// burst size and the bucket refills completely over Window.
+type RateLimitPolicy struct {
+	// Algorithm is sliding_window or token_bucket, defaults to sliding_window
+	Algorithm string   `yaml:"algorithm" validate:"omitempty,oneof=sliding_window token_bucket"`
+	Limit     int      `yaml:"limit" validate:"min=0"`
+	Window    Duration `yaml:"window" validate:"min=0"`
+	// Key is ip, user, api_key or route, defaults to ip. user and api_key fall back to the IP for anonymous requests
+	Key string `yaml:"key" validate:"omitempty,oneof=ip user api_key route"`
+}
+
+// defaultRateLimitRoutes protect the unauthenticated auth routes from credential stuffing and email flooding
+var defaultRateLimitRoutes = map[string]RateLimitPolicy{
+	"POST /api/auth/sign-in":         {Limit: 10, Window: Duration(time.Minute)},
+	"POST /api/auth/sign-up":         {Limit: 5, Window: Duration(time.Hour)},
+	"GET /api/auth/verify-user":      {Algorithm: RateLimitTokenBucket, Limit: 30, Window: Duration(time.Minute)},
+	"POST /api/auth/request-reset":   {Limit: 5, Window: Duration(15 * time.Minute)},
+	"POST /api/auth/reset-password":  {Limit: 5, Window: Duration(15 * time.Minute)},
+	"POST /api/auth/report-activity": {Limit: 5, Window: Duration(15 * time.Minute)},
+	"POST /api/otp/resend":           {Limit: 5, Window: Duration(15 * time.Minute)},
+	"POST /api/otp/validate":         {Limit: 10, Window: Duration(15 * time.Minute)},
+}
+
+// GetPolicy returns the policy for the route, ok is false when the route isn't limited
+func (r *RateLimit) GetPolicy(route string) (policy RateLimitPolicy, ok bool) {
+	if r.Disabled {
+		return RateLimitPolicy{}, false
+	}
+
+	policy, ok = r.Routes[route]
+	if !ok {
+		policy, ok = defaultRateLimitRoutes[route]
+	}
+
+	if !ok {
+		policy = r.Default
+	}
+
+	return policy, policy.IsEnabled()
+}
+
+// IsEnabled reports whether the policy limits anything
+func (p *RateLimitPolicy) IsEnabled() bool {
+	return p.Limit > 0 && p.Window > 0
+}
+
+// GetAlgorithm returns the configured algorithm or the default one
+func (p *RateLimitPolicy) GetAlgorithm() string {
+	if p.Algorithm == "" {
+		return RateLimitSlidingWindow
+	}
+
+	return p.Algorithm
+}
+
+// GetKey returns the configured key or the default one
+func (p *RateLimitPolicy) GetKey() string {
+	if p.Key == "" {
+		return RateLimitKeyIP
+	}
+
+	return p.Key
+}
+
+// String formats the policy for the RateLimit-Policy header, e.g. 10;w=60
+func (p *RateLimitPolicy) String() string {
+	return fmt.Sprintf("%d;w=%d", p.Limit, p.Window.Seconds())
+}
@@ -5,25 +5,42 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
 type RedisConfig struct {
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	Password string `yaml:"password"`
-	Database int    `yaml:"database"`
-	PoolSize int    `yaml:"poolSize"`
+	Host     string `yaml:"host" validate:"required"`
+	Port     string `yaml:"port" validate:"required"`
+	Password Secret `yaml:"password"`
+	Database int    `yaml:"database" validate:"min=0"`
+	PoolSize int    `yaml:"poolSize" validate:"min=0"`
+
+	secrets SecretsProvider
+}
+
+// GetPassword returns the current Redis password
+func (rc *RedisConfig) GetPassword(ctx context.Context) (string, error) {
+	return resolveSecret(ctx, rc.secrets, rc.Password)
 }
 
 func (rc *RedisConfig) SetupConnection() (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%s", rc.Host, rc.Port),
-		Password: rc.Password,
 		DB:       rc.Database,
 		PoolSize: rc.PoolSize,
+		// The password is read for every new connection so a rotated secret is used without a restart
+		CredentialsProviderContext: func(ctx context.Context) (string, string, error) {
+			password, err := rc.GetPassword(ctx)
+			return "", password, err
+		},
 	})
 
+	// Commands are traced with the global tracer provider, they are no-ops until tracing is set up
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, fmt.Errorf("failed to instrument Redis: %v", err)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	RegistrationModeOpen            = "open"
+	RegistrationModeInviteOnly      = "invite_only"
+	RegistrationModeDomainAllowlist = "domain_allowlist"
+
+	defaultInviteExpiration = 7 * 24 * time.Hour
+	defaultInviteMaxUses    = 1
+)
+
+// Registration holds sign-up and invitation configuration
+type Registration struct {
+	// Mode is one of open, invite_only or domain_allowlist, defaults to open
+	Mode string `yaml:"mode" validate:"omitempty,oneof=open invite_only domain_allowlist"`
+
+	// AllowedDomains lists email domains allowed to sign up without an invitation in domain_allowlist mode
+	AllowedDomains []string `yaml:"allowedDomains"`
+
+	// DefaultInviteQuota is the number of invitations a new user can create
+	DefaultInviteQuota int `yaml:"defaultInviteQuota" validate:"min=0"`
+
+	// InviteExpiration is the default invitation lifetime
+	InviteExpiration Duration `yaml:"inviteExpiration" validate:"min=0"`
+
+	// InviteMaxUses is the default number of sign-ups a single invitation allows
+	InviteMaxUses int `yaml:"inviteMaxUses" validate:"min=0"`
+
+	// InviteURL is the sign-up link sent by email, %s is replaced with the invitation code
+	InviteURL string `yaml:"inviteURL"`
+}
+
+// GetMode returns the configured registration mode or open
+func (r *Registration) GetMode() string {
+	if r.Mode == "" {
+		return RegistrationModeOpen
+	}
+
+	return r.Mode
+}
+
+// IsDomainAllowed checks if the email belongs to one of the allowed domains
+func (r *Registration) IsDomainAllowed(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[at+1:])
+	for _, allowed := range r.AllowedDomains {
+		if strings.ToLower(allowed) == domain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetInviteExpiration returns the configured invitation lifetime or the default one
+func (r *Registration) GetInviteExpiration() time.Duration {
+	if r.InviteExpiration <= 0 {
+		return defaultInviteExpiration
+	}
+
+	return r.InviteExpiration.Duration()
+}
+
+// GetInviteMaxUses returns the configured invitation max uses or the default one
+func (r *Registration) GetInviteMaxUses() int {
+	if r.InviteMaxUses <= 0 {
+		return defaultInviteMaxUses
+	}
+
+	return r.InviteMaxUses
+}
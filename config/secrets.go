@@ -0,0 +1,135 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	SecretsProviderFile  = "file"
+	SecretsProviderVault = "vault"
+
+	// secretReferencePrefix marks a value read from the secrets provider, e.g. password: secret:database/password
+	secretReferencePrefix = "secret:"
+
+	defaultSecretsCacheTTL = 5 * time.Minute
+	redactedSecret         = "******"
+)
+
+var (
+	errNoSecretsProvider = errors.New("no secrets provider configured")
+	errSecretNotFound    = errors.New("secret not found")
+)
+
+// SecretsProvider returns the current value of a named secret, e.g. database/password.
+// Implementations fetch lazily and cache values for a limited time so rotated secrets are picked up.
+type SecretsProvider interface {
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// Secret is a configuration value set inline or referencing the secrets provider with the secret: prefix
+type Secret string
+
+// Reference returns the secret name when the value references the secrets provider
+func (s Secret) Reference() (name string, ok bool) {
+	if !strings.HasPrefix(string(s), secretReferencePrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(string(s), secretReferencePrefix), true
+}
+
+// String hides inline values so secrets do not end up in logs
+func (s Secret) String() string {
+	if _, ok := s.Reference(); ok || s == "" {
+		return string(s)
+	}
+
+	return redactedSecret
+}
+
+// resolveSecret returns the inline value or fetches the referenced secret from the provider
+func resolveSecret(ctx context.Context, provider SecretsProvider, s Secret) (string, error) {
+	name, ok := s.Reference()
+	if !ok {
+		return string(s), nil
+	}
+
+	if provider == nil {
+		return "", fmt.Errorf("%s: %w", name, errNoSecretsProvider)
+	}
+
+	value, err := provider.GetSecret(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+
+	return value, nil
+}
+
+// Secrets selects where secret: references are read from
+type Secrets struct {
+	// Provider is file or vault, references are rejected when it is empty
+	Provider string `yaml:"provider" validate:"omitempty,oneof=file vault"`
+
+	// CacheTTL is how long fetched secrets are kept before being read again, defaults to 5m
+	CacheTTL Duration `yaml:"cacheTTL" validate:"min=0"`
+
+	File SecretsFile `yaml:"file"`
+
+	Vault SecretsVault `yaml:"vault"`
+}
+
+// GetCacheTTL returns the configured cache lifetime or the default one
+func (s *Secrets) GetCacheTTL() time.Duration {
+	if s.CacheTTL <= 0 {
+		return defaultSecretsCacheTTL
+	}
+
+	return s.CacheTTL.Duration()
+}
+
+// NewSecretsProvider returns the configured provider or nil when secrets are kept inline
+func NewSecretsProvider(secrets *Secrets) (SecretsProvider, error) {
+	switch secrets.Provider {
+	case "":
+		return nil, nil
+	case SecretsProviderFile:
+		return newFileSecretsProvider(&secrets.File, secrets.GetCacheTTL())
+	case SecretsProviderVault:
+		return newVaultSecretsProvider(&secrets.Vault, secrets.GetCacheTTL()), nil
+	default:
+		return nil, fmt.Errorf("unsupported secrets provider %s", secrets.Provider)
+	}
+}
+
+// attachSecrets gives the sections holding secrets access to the provider
+func (c *Config) attachSecrets(provider SecretsProvider) {
+	c.Database.secrets = provider
+	c.Redis.secrets = provider
+	c.SMTP.secrets = provider
+	c.Jwt.secrets = provider
+}
+
+// validateSecretReferences reports references that can not be resolved because there is no provider
+func validateSecretReferences(cfg *Config, fields []configField) (problems []string) {
+	if cfg.Secrets.Provider != "" {
+		return nil
+	}
+
+	for _, field := range fields {
+		secret, ok := field.value.Interface().(Secret)
+		if !ok {
+			continue
+		}
+
+		if _, ok := secret.Reference(); ok {
+			problems = append(problems, fmt.Sprintf("%s (%s): references a secret but secrets.provider is not set", field.path, field.env))
+		}
+	}
+
+	return problems
+}
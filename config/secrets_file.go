@@ -0,0 +1,166 @@
+package config
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	secretsKeySize   = 32
+	secretsNonceSize = 24
+)
+
+var errInvalidSecretsFile = errors.New("secrets file can not be decrypted, check the key")
+
+// SecretsFile is a JSON object of secrets sealed with NaCl secretbox, see EncryptSecrets
+type SecretsFile struct {
+	Path string `yaml:"path"`
+
+	// Key is the base64 encoded 32 byte key, prefer APOLLO_SECRETS_FILE_KEY_FILE over putting it in the file
+	Key string `yaml:"key"`
+}
+
+// fileSecretsProvider decrypts the secrets file on first use and again when it changes
+type fileSecretsProvider struct {
+	path     string
+	key      [secretsKeySize]byte
+	cacheTTL time.Duration
+
+	mu        sync.Mutex
+	secrets   map[string]string
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+func newFileSecretsProvider(secretsFile *SecretsFile, cacheTTL time.Duration) (SecretsProvider, error) {
+	key, err := DecodeSecretsKey(secretsFile.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSecretsProvider{
+		path:     secretsFile.Path,
+		key:      key,
+		cacheTTL: cacheTTL,
+	}, nil
+}
+
+func (fp *fileSecretsProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	if fp.secrets == nil || time.Since(fp.checkedAt) >= fp.cacheTTL {
+		if err := fp.refresh(); err != nil {
+			if fp.secrets == nil {
+				return "", err
+			}
+
+			// Keep serving the previous secrets, e.g. while the file is being replaced
+			configLogger().Warn("failed to reload secrets file, using the cached secrets", "path", fp.path, "error", err)
+			fp.checkedAt = time.Now()
+		}
+	}
+
+	value, ok := fp.secrets[name]
+	if !ok {
+		return "", errSecretNotFound
+	}
+
+	return value, nil
+}
+
+// refresh reads the file again when it was modified since the last read
+func (fp *fileSecretsProvider) refresh() error {
+	info, err := os.Stat(fp.path)
+	if err != nil {
+		return err
+	}
+
+	fp.checkedAt = time.Now()
+	if fp.secrets != nil && info.ModTime().Equal(fp.modTime) {
+		return nil
+	}
+
+	data, err := os.ReadFile(fp.path)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := DecryptSecrets(fp.key, data)
+	if err != nil {
+		return err
+	}
+
+	fp.secrets = secrets
+	fp.modTime = info.ModTime()
+
+	return nil
+}
+
+// GenerateSecretsKey returns a new base64 encoded key for the secrets file
+func GenerateSecretsKey() (string, error) {
+	var key [secretsKeySize]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key[:]), nil
+}
+
+// DecodeSecretsKey decodes a key created by GenerateSecretsKey
+func DecodeSecretsKey(encoded string) (key [secretsKeySize]byte, err error) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(decoded) != secretsKeySize {
+		return key, fmt.Errorf("secrets key must be %d base64 encoded bytes", secretsKeySize)
+	}
+
+	copy(key[:], decoded)
+
+	return key, nil
+}
+
+// EncryptSecrets seals the secrets as a random nonce followed by the secretbox of their JSON encoding
+func EncryptSecrets(key [secretsKeySize]byte, secrets map[string]string) ([]byte, error) {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [secretsNonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	return secretbox.Seal(nonce[:], plain, &nonce, &key), nil
+}
+
+// DecryptSecrets opens data created by EncryptSecrets
+func DecryptSecrets(key [secretsKeySize]byte, data []byte) (map[string]string, error) {
+	if len(data) < secretsNonceSize+secretbox.Overhead {
+		return nil, errInvalidSecretsFile
+	}
+
+	var nonce [secretsNonceSize]byte
+	copy(nonce[:], data[:secretsNonceSize])
+
+	plain, ok := secretbox.Open(nil, data[secretsNonceSize:], &nonce, &key)
+	if !ok {
+		return nil, errInvalidSecretsFile
+	}
+
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("invalid secrets file: %w", err)
+	}
+
+	return secrets, nil
+}
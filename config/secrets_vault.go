@@ -0,0 +1,288 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultVaultMount        = "secret"
+	defaultVaultAppRoleMount = "approle"
+	defaultVaultTimeout      = 5 * time.Second
+
+	// vaultTokenRenewMargin logs in again this long before an AppRole token expires
+	vaultTokenRenewMargin = 30 * time.Second
+
+	vaultTokenHeader     = "X-Vault-Token"
+	vaultNamespaceHeader = "X-Vault-Namespace"
+	vaultMaxBodySize     = 1 << 20
+)
+
+var errVaultPermissionDenied = errors.New("vault responded with status 403")
+
+// SecretsVault reads secrets from a Vault compatible KV version 2 engine.
+// A secret named database/password is the password key of the database secret.
+type SecretsVault struct {
+	Address string `yaml:"address" validate:"omitempty,url"`
+
+	// Token is sent as X-Vault-Token, prefer APOLLO_SECRETS_VAULT_TOKEN_FILE over putting it in the file
+	Token string `yaml:"token"`
+
+	// RoleID and SecretID log in with AppRole when Token is empty, prefer APOLLO_SECRETS_VAULT_SECRET_ID_FILE for the secret ID
+	RoleID   string `yaml:"roleId"`
+	SecretID string `yaml:"secretId"`
+
+	// AppRoleMount is the AppRole auth mount path, defaults to approle
+	AppRoleMount string `yaml:"appRoleMount"`
+
+	// Mount is the KV engine mount path, defaults to secret
+	Mount string `yaml:"mount"`
+
+	Namespace string `yaml:"namespace"`
+
+	Timeout Duration `yaml:"timeout" validate:"min=0"`
+}
+
+// GetMount returns the configured mount path or the default one
+func (v *SecretsVault) GetMount() string {
+	if v.Mount == "" {
+		return defaultVaultMount
+	}
+
+	return strings.Trim(v.Mount, "/")
+}
+
+// GetAppRoleMount returns the configured AppRole auth mount path or the default one
+func (v *SecretsVault) GetAppRoleMount() string {
+	if v.AppRoleMount == "" {
+		return defaultVaultAppRoleMount
+	}
+
+	return strings.Trim(v.AppRoleMount, "/")
+}
+
+// UsesAppRole reports whether the provider logs in with AppRole instead of a static token
+func (v *SecretsVault) UsesAppRole() bool {
+	return v.Token == "" && v.RoleID != ""
+}
+
+// GetTimeout returns the configured request timeout or the default one
+func (v *SecretsVault) GetTimeout() time.Duration {
+	if v.Timeout <= 0 {
+		return defaultVaultTimeout
+	}
+
+	return v.Timeout.Duration()
+}
+
+type vaultSecret struct {
+	data      map[string]string
+	fetchedAt time.Time
+}
+
+type vaultResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+type vaultLoginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+// vaultSecretsProvider fetches a secret path on first use and again once the cache expired.
+// A failed refresh keeps serving the previous value so a Vault outage does not break running connections.
+// With AppRole the client token is kept until shortly before its lease ends, or until Vault rejects it.
+type vaultSecretsProvider struct {
+	config   *SecretsVault
+	client   *http.Client
+	cacheTTL time.Duration
+
+	mu             sync.Mutex
+	cache          map[string]vaultSecret
+	token          string
+	tokenExpiresAt time.Time
+}
+
+func newVaultSecretsProvider(vault *SecretsVault, cacheTTL time.Duration) SecretsProvider {
+	return &vaultSecretsProvider{
+		config:   vault,
+		client:   &http.Client{Timeout: vault.GetTimeout()},
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]vaultSecret),
+	}
+}
+
+func (vp *vaultSecretsProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	index := strings.LastIndex(name, "/")
+	if index <= 0 || index == len(name)-1 {
+		return "", fmt.Errorf("secret name %q must be <path>/<key>", name)
+	}
+
+	path, key := name[:index], name[index+1:]
+
+	vp.mu.Lock()
+	defer vp.mu.Unlock()
+
+	secret, ok := vp.cache[path]
+	if !ok || time.Since(secret.fetchedAt) >= vp.cacheTTL {
+		data, err := vp.fetch(ctx, path)
+		if err != nil {
+			if !ok {
+				return "", err
+			}
+
+			// Wait for another cache lifetime before retrying instead of calling Vault on every read
+			configLogger().Warn("failed to refresh secret, using the cached value", "path", path, "error", err)
+			secret.fetchedAt = time.Now()
+		} else {
+			secret = vaultSecret{data: data, fetchedAt: time.Now()}
+		}
+
+		vp.cache[path] = secret
+	}
+
+	value, ok := secret.data[key]
+	if !ok {
+		return "", errSecretNotFound
+	}
+
+	return value, nil
+}
+
+func (vp *vaultSecretsProvider) fetch(ctx context.Context, path string) (map[string]string, error) {
+	data, err := vp.read(ctx, path)
+	if errors.Is(err, errVaultPermissionDenied) && vp.config.UsesAppRole() {
+		// The AppRole token may have been revoked before its lease ended, log in once more
+		vp.token = ""
+		data, err = vp.read(ctx, path)
+	}
+
+	return data, err
+}
+
+func (vp *vaultSecretsProvider) read(ctx context.Context, path string) (map[string]string, error) {
+	token, err := vp.clientToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := url.JoinPath(vp.config.Address, "v1", vp.config.GetMount(), "data", path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(vaultTokenHeader, token)
+
+	var body vaultResponse
+	if err := vp.do(req, &body); err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]string, len(body.Data.Data))
+	for key, value := range body.Data.Data {
+		if s, ok := value.(string); ok {
+			data[key] = s
+			continue
+		}
+
+		data[key] = fmt.Sprint(value)
+	}
+
+	return data, nil
+}
+
+// clientToken returns the static token or a valid AppRole token, logging in when there is none
+func (vp *vaultSecretsProvider) clientToken(ctx context.Context) (string, error) {
+	if !vp.config.UsesAppRole() {
+		return vp.config.Token, nil
+	}
+
+	if vp.token != "" && (vp.tokenExpiresAt.IsZero() || time.Now().Before(vp.tokenExpiresAt)) {
+		return vp.token, nil
+	}
+
+	endpoint, err := url.JoinPath(vp.config.Address, "v1", "auth", vp.config.GetAppRoleMount(), "login")
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"role_id":   vp.config.RoleID,
+		"secret_id": vp.config.SecretID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	var body vaultLoginResponse
+	if err := vp.do(req, &body); err != nil {
+		return "", fmt.Errorf("vault approle login: %w", err)
+	}
+
+	if body.Auth.ClientToken == "" {
+		return "", errors.New("vault approle login: response has no client token")
+	}
+
+	vp.token = body.Auth.ClientToken
+	vp.tokenExpiresAt = time.Time{}
+	if body.Auth.LeaseDuration > 0 {
+		lease := time.Duration(body.Auth.LeaseDuration) * time.Second
+		vp.tokenExpiresAt = time.Now().Add(lease - min(vaultTokenRenewMargin, lease/2))
+	}
+
+	return vp.token, nil
+}
+
+// do sends the request with the namespace header and decodes a successful JSON response into out
+func (vp *vaultSecretsProvider) do(req *http.Request, out interface{}) error {
+	if vp.config.Namespace != "" {
+		req.Header.Set(vaultNamespaceHeader, vp.config.Namespace)
+	}
+
+	resp, err := vp.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errSecretNotFound
+	case resp.StatusCode == http.StatusForbidden:
+		return errVaultPermissionDenied
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("vault responded with status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, vaultMaxBodySize)).Decode(out); err != nil {
+		return fmt.Errorf("invalid vault response: %w", err)
+	}
+
+	return nil
+}
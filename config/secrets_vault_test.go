@@ -0,0 +1,374 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+)
+
+// vaultStub is a minimal Vault server with a KV version 2 engine and an AppRole auth method
+type vaultStub struct {
+	mount     string
+	namespace string
+
+	mu      sync.Mutex
+	secrets map[string]map[string]interface{}
+
+	roleID        string
+	secretID      string
+	leaseDuration int64
+
+	token       atomic.Value // string, the currently valid token
+	reads       atomic.Int32
+	logins      atomic.Int32
+	readStatus  atomic.Int32 // forces the status of secret reads when not zero
+	invalidJSON atomic.Bool
+}
+
+func newVaultStub(t *testing.T, token string) (*vaultStub, *httptest.Server) {
+	stub := &vaultStub{
+		mount: defaultVaultMount,
+		secrets: map[string]map[string]interface{}{
+			"database": {"password": "db-secret", "port": 5432},
+		},
+	}
+	stub.token.Store(token)
+
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	return stub, server
+}
+
+func (s *vaultStub) setSecret(path string, key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.secrets[path][key] = value
+}
+
+func (s *vaultStub) validToken() string {
+	return s.token.Load().(string)
+}
+
+func (s *vaultStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.namespace != "" && r.Header.Get(vaultNamespaceHeader) != s.namespace {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if r.URL.Path == "/v1/auth/approle/login" {
+		s.login(w, r)
+		return
+	}
+
+	prefix := "/v1/" + s.mount + "/data/"
+	if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.reads.Add(1)
+
+	if r.Header.Get(vaultTokenHeader) != s.validToken() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if status := s.readStatus.Load(); status != 0 {
+		w.WriteHeader(int(status))
+		return
+	}
+
+	if s.invalidJSON.Load() {
+		_, _ = w.Write([]byte("{"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.secrets[strings.TrimPrefix(r.URL.Path, prefix)]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{"data": data},
+	})
+}
+
+func (s *vaultStub) login(w http.ResponseWriter, r *http.Request) {
+	s.logins.Add(1)
+
+	var body struct {
+		RoleID   string `json:"role_id"`
+		SecretID string `json:"secret_id"`
+	}
+	if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&body) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if body.RoleID != s.roleID || body.SecretID != s.secretID {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	token := "approle-token-" + time.Now().Format(time.RFC3339Nano)
+	s.token.Store(token)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"auth": map[string]interface{}{"client_token": token, "lease_duration": s.leaseDuration},
+	})
+}
+
+func TestVaultSecretsProviderReadsKVv2(t *testing.T) {
+	stub, server := newVaultStub(t, "static-token")
+	stub.mount = "kv"
+	stub.namespace = "team"
+
+	provider := newVaultSecretsProvider(&SecretsVault{
+		Address:   server.URL,
+		Token:     "static-token",
+		Mount:     "/kv/",
+		Namespace: "team",
+	}, time.Minute)
+
+	password, err := provider.GetSecret(context.Background(), "database/password")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+
+	if password != "db-secret" {
+		t.Errorf("GetSecret() = %q, want %q", password, "db-secret")
+	}
+
+	port, err := provider.GetSecret(context.Background(), "database/port")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+
+	if port != "5432" {
+		t.Errorf("GetSecret() = %q, want non-string values formatted, %q", port, "5432")
+	}
+
+	if reads := stub.reads.Load(); reads != 1 {
+		t.Errorf("vault reads = %d, want the path to be cached after 1 read", reads)
+	}
+}
+
+func TestVaultSecretsProviderRefreshesExpiredCache(t *testing.T) {
+	stub, server := newVaultStub(t, "static-token")
+	provider := newVaultSecretsProvider(&SecretsVault{Address: server.URL, Token: "static-token"}, time.Nanosecond)
+
+	if _, err := provider.GetSecret(context.Background(), "database/password"); err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+
+	stub.setSecret("database", "password", "rotated")
+
+	password, err := provider.GetSecret(context.Background(), "database/password")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+
+	if password != "rotated" {
+		t.Errorf("GetSecret() = %q, want the rotated value", password)
+	}
+}
+
+func TestVaultSecretsProviderServesStaleValueOnRefreshFailure(t *testing.T) {
+	stub, server := newVaultStub(t, "static-token")
+	provider := newVaultSecretsProvider(&SecretsVault{Address: server.URL, Token: "static-token"}, time.Nanosecond)
+
+	if _, err := provider.GetSecret(context.Background(), "database/password"); err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+
+	stub.readStatus.Store(http.StatusServiceUnavailable)
+
+	password, err := provider.GetSecret(context.Background(), "database/password")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v, want the cached value", err)
+	}
+
+	if password != "db-secret" {
+		t.Errorf("GetSecret() = %q, want the cached value %q", password, "db-secret")
+	}
+}
+
+func TestVaultSecretsProviderErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		token     string
+		setup     func(stub *vaultStub)
+		wantErr   error
+		wantInErr string
+	}{
+		{name: "name without key", secret: "database", wantInErr: "must be <path>/<key>"},
+		{name: "name with trailing slash", secret: "database/", wantInErr: "must be <path>/<key>"},
+		{name: "missing path", secret: "cache/password", wantErr: errSecretNotFound},
+		{name: "missing key", secret: "database/username", wantErr: errSecretNotFound},
+		{name: "invalid token", secret: "database/password", token: "wrong", wantErr: errVaultPermissionDenied},
+		{
+			name:      "server error",
+			secret:    "database/password",
+			setup:     func(stub *vaultStub) { stub.readStatus.Store(http.StatusInternalServerError) },
+			wantInErr: "vault responded with status 500",
+		},
+		{
+			name:      "invalid response",
+			secret:    "database/password",
+			setup:     func(stub *vaultStub) { stub.invalidJSON.Store(true) },
+			wantInErr: "invalid vault response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub, server := newVaultStub(t, "static-token")
+			if tt.setup != nil {
+				tt.setup(stub)
+			}
+
+			token := tt.token
+			if token == "" {
+				token = "static-token"
+			}
+
+			provider := newVaultSecretsProvider(&SecretsVault{Address: server.URL, Token: token}, time.Minute)
+
+			_, err := provider.GetSecret(context.Background(), tt.secret)
+			if err == nil {
+				t.Fatal("GetSecret() error = nil, want an error")
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetSecret() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantInErr != "" && !strings.Contains(err.Error(), tt.wantInErr) {
+				t.Errorf("GetSecret() error = %v, want it to contain %q", err, tt.wantInErr)
+			}
+		})
+	}
+}
+
+func TestVaultSecretsProviderAppRole(t *testing.T) {
+	stub, server := newVaultStub(t, "")
+	stub.roleID = "role"
+	stub.secretID = "secret"
+	stub.leaseDuration = 3600
+
+	provider := newVaultSecretsProvider(&SecretsVault{
+		Address:  server.URL,
+		RoleID:   "role",
+		SecretID: "secret",
+	}, time.Nanosecond)
+
+	for i := 0; i < 2; i++ {
+		password, err := provider.GetSecret(context.Background(), "database/password")
+		if err != nil {
+			t.Fatalf("GetSecret() error = %v", err)
+		}
+
+		if password != "db-secret" {
+			t.Errorf("GetSecret() = %q, want %q", password, "db-secret")
+		}
+	}
+
+	if logins := stub.logins.Load(); logins != 1 {
+		t.Errorf("approle logins = %d, want the token to be reused", logins)
+	}
+}
+
+func TestVaultSecretsProviderAppRoleLogsInAgainWhenTokenIsRevoked(t *testing.T) {
+	stub, server := newVaultStub(t, "")
+	stub.roleID = "role"
+	stub.secretID = "secret"
+	stub.leaseDuration = 3600
+
+	provider := newVaultSecretsProvider(&SecretsVault{
+		Address:  server.URL,
+		RoleID:   "role",
+		SecretID: "secret",
+	}, time.Nanosecond)
+
+	if _, err := provider.GetSecret(context.Background(), "database/password"); err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+
+	stub.token.Store("revoked")
+	stub.setSecret("database", "password", "rotated")
+
+	password, err := provider.GetSecret(context.Background(), "database/password")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+
+	if password != "rotated" {
+		t.Errorf("GetSecret() = %q, want the value read with the new token", password)
+	}
+
+	if logins := stub.logins.Load(); logins != 2 {
+		t.Errorf("approle logins = %d, want a second login after the token was rejected", logins)
+	}
+}
+
+func TestVaultSecretsProviderAppRoleLoginFailure(t *testing.T) {
+	stub, server := newVaultStub(t, "")
+	stub.roleID = "role"
+	stub.secretID = "secret"
+
+	provider := newVaultSecretsProvider(&SecretsVault{
+		Address:  server.URL,
+		RoleID:   "role",
+		SecretID: "wrong",
+	}, time.Minute)
+
+	_, err := provider.GetSecret(context.Background(), "database/password")
+	if err == nil || !strings.Contains(err.Error(), "vault approle login") {
+		t.Fatalf("GetSecret() error = %v, want an approle login error", err)
+	}
+
+	if reads := stub.reads.Load(); reads != 0 {
+		t.Errorf("vault reads = %d, want none without a token", reads)
+	}
+}
+
+func TestValidateVaultAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		vault   SecretsVault
+		wantErr bool
+	}{
+		{name: "token", vault: SecretsVault{Address: "https://vault.example.com", Token: "token"}},
+		{name: "approle", vault: SecretsVault{Address: "https://vault.example.com", RoleID: "role", SecretID: "secret"}},
+		{name: "no auth", vault: SecretsVault{Address: "https://vault.example.com"}, wantErr: true},
+		{name: "approle without secret id", vault: SecretsVault{Address: "https://vault.example.com", RoleID: "role"}, wantErr: true},
+	}
+
+	v := validator.New()
+	v.RegisterStructValidation(validateSecrets, Secrets{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(Secrets{Provider: SecretsProviderVault, Vault: tt.vault})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
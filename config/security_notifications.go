@@ -0,0 +1,27 @@
+package config
+
+import "time"
+
+const defaultReportTokenExpiration = 7 * 24 * time.Hour
+
+// SecurityNotifications configures the emails sent for sensitive account events,
+// e.g. a sign-in from a new device or a password change
+type SecurityNotifications struct {
+	Disabled bool `yaml:"disabled"`
+
+	// ReportURL is the "this wasn't me" link, %s is replaced with a one-time token.
+	// The page behind it posts the token to /api/auth/report-activity. Emails have no link when it is empty.
+	ReportURL string `yaml:"reportURL"`
+
+	// ReportTokenExpiration is how long the link works, defaults to 7 days
+	ReportTokenExpiration Duration `yaml:"reportTokenExpiration" validate:"min=0"`
+}
+
+// GetReportTokenExpiration returns the configured report token lifetime or the default one
+func (s *SecurityNotifications) GetReportTokenExpiration() time.Duration {
+	if s.ReportTokenExpiration <= 0 {
+		return defaultReportTokenExpiration
+	}
+
+	return s.ReportTokenExpiration.Duration()
+}
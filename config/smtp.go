@@ -1,9 +1,93 @@
 package config
 
+import (
+	"context"
+	"time"
+)
+
+const (
+	SMTPTransportSMTP    = "smtp"
+	SMTPTransportMailbox = "mailbox"
+
+	defaultSMTPPoolSize    = 2
+	defaultSMTPIdleTimeout = 30 * time.Second
+	defaultSMTPTimeout     = 30 * time.Second
+	defaultMailboxSize     = 100
+)
+
 // SMTPConfig holds SMTP server configuration
 type SMTPConfig struct {
+	// Transport is either smtp or mailbox, defaults to smtp. The mailbox keeps emails in memory
+	// and shows them on /dev/mail instead of sending them, it is refused in production.
+	Transport string `yaml:"transport" validate:"omitempty,oneof=smtp mailbox"`
+
 	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
-	Sender   string `yaml:"sender"`
-	Password string `yaml:"password"`
+	Port     int    `yaml:"port" validate:"omitempty,min=1,max=65535"`
+	Sender   string `yaml:"sender" validate:"required"`
+	Password Secret `yaml:"password"`
+
+	// PoolSize is how many connections are kept open to the server, defaults to 2
+	PoolSize int `yaml:"poolSize" validate:"min=0"`
+	// IdleTimeout closes a pooled connection unused for that long, before the server does. Defaults to 30s
+	IdleTimeout Duration `yaml:"idleTimeout" validate:"min=0"`
+	// Timeout limits sending one email, including waiting for a connection. Defaults to 30s
+	Timeout Duration `yaml:"timeout" validate:"min=0"`
+
+	// MailboxSize is how many emails the mailbox keeps, the oldest are dropped first. Defaults to 100
+	MailboxSize int `yaml:"mailboxSize" validate:"min=0"`
+
+	// TemplateDir holds optional email templates that replace the built-in ones with the same file name
+	TemplateDir string `yaml:"templateDir"`
+
+	secrets SecretsProvider
+}
+
+// GetPassword returns the current SMTP password
+func (s *SMTPConfig) GetPassword(ctx context.Context) (string, error) {
+	return resolveSecret(ctx, s.secrets, s.Password)
+}
+
+// GetTransport returns the configured transport or smtp
+func (s *SMTPConfig) GetTransport() string {
+	if s.Transport == "" {
+		return SMTPTransportSMTP
+	}
+
+	return s.Transport
+}
+
+// GetPoolSize returns the configured pool size or the default one
+func (s *SMTPConfig) GetPoolSize() int {
+	if s.PoolSize <= 0 {
+		return defaultSMTPPoolSize
+	}
+
+	return s.PoolSize
+}
+
+// GetIdleTimeout returns the configured idle timeout or the default one
+func (s *SMTPConfig) GetIdleTimeout() time.Duration {
+	if s.IdleTimeout <= 0 {
+		return defaultSMTPIdleTimeout
+	}
+
+	return s.IdleTimeout.Duration()
+}
+
+// GetTimeout returns the configured send timeout or the default one
+func (s *SMTPConfig) GetTimeout() time.Duration {
+	if s.Timeout <= 0 {
+		return defaultSMTPTimeout
+	}
+
+	return s.Timeout.Duration()
+}
+
+// GetMailboxSize returns the configured mailbox size or the default one
+func (s *SMTPConfig) GetMailboxSize() int {
+	if s.MailboxSize <= 0 {
+		return defaultMailboxSize
+	}
+
+	return s.MailboxSize
 }
@@ -0,0 +1,52 @@
+package config
+
+const (
+	StorageDriverLocal = "local"
+	StorageDriverS3    = "s3"
+
+	defaultLocalStorageDirectory = "files/storage"
+	defaultLocalStorageBaseURL   = "/storage"
+)
+
+// Storage holds file storage configuration
+type Storage struct {
+	// Driver is either local or s3, defaults to local
+	Driver string       `yaml:"driver" validate:"omitempty,oneof=local s3"`
+	Local  LocalStorage `yaml:"local"`
+	S3     S3Storage    `yaml:"s3"`
+}
+
+// LocalStorage holds configuration for storing files on the local disk
+type LocalStorage struct {
+	Directory string `yaml:"directory"`
+	BaseURL   string `yaml:"baseURL"` // path the directory is served from, e.g. /storage
+}
+
+// GetDirectory returns the configured directory or the default one
+func (l *LocalStorage) GetDirectory() string {
+	if l.Directory == "" {
+		return defaultLocalStorageDirectory
+	}
+
+	return l.Directory
+}
+
+// GetBaseURL returns the configured base URL or the default one
+func (l *LocalStorage) GetBaseURL() string {
+	if l.BaseURL == "" {
+		return defaultLocalStorageBaseURL
+	}
+
+	return l.BaseURL
+}
+
+// S3Storage holds configuration for an S3 compatible object storage
+type S3Storage struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"accessKey"`
+	SecretKey string `yaml:"secretKey"`
+	UseSSL    bool   `yaml:"useSSL"`
+	BaseURL   string `yaml:"baseURL"`
+}
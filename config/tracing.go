@@ -0,0 +1,60 @@
+package config
+
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+
+	defaultTracingEndpoint    = "localhost:4318"
+	defaultTracingFilePath    = "files/traces.jsonl"
+	defaultTracingSampleRatio = 1
+)
+
+// Tracing configures OpenTelemetry tracing, incoming W3C trace context is propagated even when it is disabled
+type Tracing struct {
+	Enabled bool `yaml:"enabled"`
+	// Exporter is otlp, stdout or file, defaults to otlp
+	Exporter string `yaml:"exporter" validate:"omitempty,oneof=otlp stdout file"`
+	Endpoint string `yaml:"endpoint"` // OTLP/HTTP collector host:port, defaults to localhost:4318
+	Insecure bool   `yaml:"insecure"` // send OTLP over plain HTTP
+	FilePath string `yaml:"filePath"` // where the file exporter appends spans
+	// SampleRatio is the share of new traces recorded, 0 records none and unset defaults to 1.
+	// Traces started upstream follow the caller's decision
+	SampleRatio *float64 `yaml:"sampleRatio" validate:"omitempty,min=0,max=1"`
+}
+
+// GetExporter returns the configured exporter or the default one
+func (t *Tracing) GetExporter() string {
+	if t.Exporter == "" {
+		return TracingExporterOTLP
+	}
+
+	return t.Exporter
+}
+
+// GetEndpoint returns the configured OTLP endpoint or the default one
+func (t *Tracing) GetEndpoint() string {
+	if t.Endpoint == "" {
+		return defaultTracingEndpoint
+	}
+
+	return t.Endpoint
+}
+
+// GetFilePath returns the configured trace file or the default one
+func (t *Tracing) GetFilePath() string {
+	if t.FilePath == "" {
+		return defaultTracingFilePath
+	}
+
+	return t.FilePath
+}
+
+// GetSampleRatio returns the configured sample ratio or the default one when it is unset
+func (t *Tracing) GetSampleRatio() float64 {
+	if t.SampleRatio == nil {
+		return defaultTracingSampleRatio
+	}
+
+	return *t.SampleRatio
+}
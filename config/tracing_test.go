@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTracingSampleRatio(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		env  string
+		want float64
+	}{
+		{name: "unset", yaml: "enabled: true", want: defaultTracingSampleRatio},
+		{name: "empty", yaml: "sampleRatio:", want: defaultTracingSampleRatio},
+		{name: "zero never samples", yaml: "sampleRatio: 0", want: 0},
+		{name: "ratio", yaml: "sampleRatio: 0.25", want: 0.25},
+		{name: "zero from env", yaml: "sampleRatio: 0.25", env: "0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			if err := yaml.Unmarshal([]byte(tt.yaml), &cfg.Tracing); err != nil {
+				t.Fatalf("yaml.Unmarshal() error = %v", err)
+			}
+
+			if tt.env != "" {
+				t.Setenv("APOLLO_TRACING_SAMPLE_RATIO", tt.env)
+
+				if problems := applyEnvOverrides(collectFields(&cfg)); len(problems) != 0 {
+					t.Fatalf("applyEnvOverrides() problems = %v", problems)
+				}
+			}
+
+			if got := cfg.Tracing.GetSampleRatio(); got != tt.want {
+				t.Errorf("GetSampleRatio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
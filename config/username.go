@@ -0,0 +1,39 @@
+package config
+
+const (
+	defaultUsernameSuggestionCount = 3
+)
+
+var defaultReservedUsernames = []string{
+	"admin",
+	"administrator",
+	"root",
+	"support",
+	"system",
+	"help",
+	"apollo",
+}
+
+// Username holds username related configuration
+type Username struct {
+	SuggestionCount int      `yaml:"suggestionCount"`
+	ReservedWords   []string `yaml:"reservedWords"`
+}
+
+// GetSuggestionCount returns the configured suggestion count or the default one
+func (u *Username) GetSuggestionCount() int {
+	if u.SuggestionCount <= 0 {
+		return defaultUsernameSuggestionCount
+	}
+
+	return u.SuggestionCount
+}
+
+// GetReservedWords returns the configured reserved words or the default list
+func (u *Username) GetReservedWords() []string {
+	if len(u.ReservedWords) == 0 {
+		return defaultReservedUsernames
+	}
+
+	return u.ReservedWords
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator"
+)
+
+const (
+	tagRequiredIf = "required_if"
+)
+
+var sliceIndexPattern = regexp.MustCompile(`\[\d+\]`)
+
+// requiredField is a field a struct level validation requires depending on another field
+type requiredField struct {
+	name  string
+	value string
+}
+
+// ValidationError lists every problem found in the configuration, so they can be fixed in one go
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// validateConfig checks the validate tags and the rules depending on other fields
+func validateConfig(cfg *Config, fields []configField) (problems []string) {
+	byNamespace := make(map[string]configField, len(fields))
+	for _, field := range fields {
+		byNamespace[field.namespace] = field
+	}
+
+	v := validator.New()
+	v.RegisterStructValidation(validateStorage, Storage{})
+	v.RegisterStructValidation(validateRegistration, Registration{})
+	v.RegisterStructValidation(validateCountryRefresh, CountryRefresh{})
+	v.RegisterStructValidation(validateSecrets, Secrets{})
+	v.RegisterStructValidation(validateSMTP, SMTPConfig{})
+
+	err := v.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []string{err.Error()}
+	}
+
+	for _, fe := range validationErrors {
+		namespace := sliceIndexPattern.ReplaceAllString(fe.Namespace(), "")
+		field, ok := byNamespace[namespace]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: %s", fe.Namespace(), describeValidationError(fe)))
+			continue
+		}
+
+		problems = append(problems, fmt.Sprintf("%s (%s): %s", field.path, field.env, describeValidationError(fe)))
+	}
+
+	return problems
+}
+
+// validateProduction rejects settings only meant for development
+func validateProduction(cfg *Config) (problems []string) {
+	if !cfg.IsProduction() {
+		return nil
+	}
+
+	if cfg.SMTP.GetTransport() == SMTPTransportMailbox {
+		problems = append(problems, "smtp.transport (APOLLO_SMTP_TRANSPORT): the mailbox transport can not be used in production")
+	}
+
+	return problems
+}
+
+func validateStorage(sl validator.StructLevel) {
+	storage := sl.Current().Interface().(Storage)
+	if storage.Driver != StorageDriverS3 {
+		return
+	}
+
+	required := []requiredField{
+		{"S3.Endpoint", storage.S3.Endpoint},
+		{"S3.Bucket", storage.S3.Bucket},
+		{"S3.AccessKey", storage.S3.AccessKey},
+		{"S3.SecretKey", storage.S3.SecretKey},
+	}
+
+	for _, field := range required {
+		if field.value == "" {
+			sl.ReportError(field.value, field.name, field.name, tagRequiredIf, "storage.driver is s3")
+		}
+	}
+}
+
+func validateRegistration(sl validator.StructLevel) {
+	registration := sl.Current().Interface().(Registration)
+	if registration.GetMode() == RegistrationModeDomainAllowlist && len(registration.AllowedDomains) == 0 {
+		sl.ReportError(registration.AllowedDomains, "AllowedDomains", "AllowedDomains", tagRequiredIf, "registration.mode is domain_allowlist")
+	}
+}
+
+func validateCountryRefresh(sl validator.StructLevel) {
+	refresh := sl.Current().Interface().(CountryRefresh)
+	if refresh.Enabled && refresh.UpstreamURL == "" {
+		sl.ReportError(refresh.UpstreamURL, "UpstreamURL", "UpstreamURL", tagRequiredIf, "country.refresh.enabled is true")
+	}
+}
+
+func validateSecrets(sl validator.StructLevel) {
+	secrets := sl.Current().Interface().(Secrets)
+
+	var required []requiredField
+
+	switch secrets.Provider {
+	case SecretsProviderFile:
+		required = append(required,
+			requiredField{"File.Path", secrets.File.Path},
+			requiredField{"File.Key", secrets.File.Key},
+		)
+	case SecretsProviderVault:
+		required = append(required, requiredField{"Vault.Address", secrets.Vault.Address})
+		if secrets.Vault.Token == "" && secrets.Vault.RoleID == "" {
+			sl.ReportError(secrets.Vault.Token, "Vault.Token", "Vault.Token", tagRequiredIf, "secrets.provider is vault and vault.roleId is empty")
+		}
+
+		if secrets.Vault.UsesAppRole() {
+			required = append(required, requiredField{"Vault.SecretID", secrets.Vault.SecretID})
+		}
+	}
+
+	for _, field := range required {
+		if field.value == "" {
+			sl.ReportError(field.value, field.name, field.name, tagRequiredIf, "secrets.provider is "+secrets.Provider)
+		}
+	}
+}
+
+func validateSMTP(sl validator.StructLevel) {
+	smtp := sl.Current().Interface().(SMTPConfig)
+	if smtp.GetTransport() != SMTPTransportSMTP {
+		return
+	}
+
+	if smtp.Host == "" {
+		sl.ReportError(smtp.Host, "Host", "Host", tagRequiredIf, "smtp.transport is smtp")
+	}
+
+	if smtp.Port == 0 {
+		sl.ReportError(smtp.Port, "Port", "Port", tagRequiredIf, "smtp.transport is smtp")
+	}
+}
+
+func describeValidationError(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case tagRequiredIf:
+		return "is required when " + fe.Param()
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "len":
+		return "must be exactly " + fe.Param() + " characters"
+	case "url":
+		return "must be a valid URL"
+	default:
+		return "failed the " + fe.Tag() + " check"
+	}
+}
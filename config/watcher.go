@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultWatchInterval = 5 * time.Second
+
+	reloadTag        = "reload"
+	reloadTagRestart = "restart"
+)
+
+// Watcher keeps the configuration currently in effect and reloads it on SIGHUP or when the file changes.
+// Sections of Config tagged reload:"restart" are only read at start up, changes to them are kept out of the
+// snapshot and reported as requiring a restart.
+type Watcher struct {
+	current atomic.Pointer[Config]
+
+	path     string
+	interval time.Duration
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher returns a watcher serving cfg until the first reload
+func NewWatcher(cfg *Config) *Watcher {
+	w := &Watcher{
+		interval: defaultWatchInterval,
+	}
+
+	w.path, _ = configPath(cfg.Environment)
+	w.modTime, w.size = w.stat()
+	w.current.Store(cfg)
+
+	return w
+}
+
+// Current returns the latest configuration snapshot, it must not be modified
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Start reloads the configuration on SIGHUP or when the file changes until ctx is done
+func (w *Watcher) Start(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			w.reload("SIGHUP")
+		case <-ticker.C:
+			if w.changed() {
+				w.reload("file change")
+			}
+		}
+	}
+}
+
+// Reload loads and validates the configuration and swaps the snapshot.
+// It returns the changed fields that only take effect after a restart, the previous snapshot is kept on error.
+func (w *Watcher) Reload() (restartRequired []string, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.modTime, w.size = w.stat()
+
+	next, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	restartRequired = keepRestartRequired(w.current.Load(), next)
+	w.current.Store(next)
+
+	return restartRequired, nil
+}
+
+func (w *Watcher) reload(reason string) {
+	restartRequired, err := w.Reload()
+	if err != nil {
+		configLogger().Error("config reload failed, keeping the current configuration", "reason", reason, "error", err)
+		return
+	}
+
+	configLogger().Info("config reloaded", "reason", reason)
+	if len(restartRequired) > 0 {
+		configLogger().Warn("config changes require a restart to take effect", "fields", restartRequired)
+	}
+}
+
+func (w *Watcher) changed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	modTime, size := w.stat()
+	return !modTime.Equal(w.modTime) || size != w.size
+}
+
+func (w *Watcher) stat() (time.Time, int64) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return time.Time{}, 0
+	}
+
+	return info.ModTime(), info.Size()
+}
+
+// keepRestartRequired copies the sections tagged reload:"restart" from current into next
+// and returns the paths of the fields that differ in them
+func keepRestartRequired(current *Config, next *Config) (changed []string) {
+	restartSections := make(map[string]bool)
+
+	currentValue := reflect.ValueOf(current).Elem()
+	nextValue := reflect.ValueOf(next).Elem()
+	t := currentValue.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get(reloadTag) == reloadTagRestart {
+			restartSections[yamlKey(t.Field(i))] = true
+		}
+	}
+
+	currentFields := collectFields(current)
+	nextFields := collectFields(next)
+	for i := range currentFields {
+		section := strings.SplitN(currentFields[i].path, ".", 2)[0]
+		if !restartSections[section] {
+			continue
+		}
+
+		if !reflect.DeepEqual(currentFields[i].value.Interface(), nextFields[i].value.Interface()) {
+			changed = append(changed, currentFields[i].path)
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get(reloadTag) == reloadTagRestart {
+			nextValue.Field(i).Set(currentValue.Field(i))
+		}
+	}
+
+	return changed
+}
@@ -15,6 +15,150 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/audit-events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the authentication and account events of every user, newest first. Admins only, services send an API key instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List audit events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key, used instead of an admin's bearer token",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Account the event is about",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User who caused the event",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. signed_in or password_changed",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "success or failure",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client IP address",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page, defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Events per page, defaults to 20, at most 100",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit events",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginateResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/dto.AuditEventResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/refresh": {
             "post": {
                 "security": [
@@ -67,6 +211,70 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/report-activity": {
+            "post": {
+                "description": "Revoke every access and refresh token of the user and send an OTP for password reset, using the token of a security notification email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Report unrecognized account activity",
+                "parameters": [
+                    {
+                        "description": "Report Activity Request Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportActivityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Response containing OTP info",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RequestResetResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/request-reset": {
             "post": {
                 "description": "Send OTP to user's email for password reset",
@@ -250,6 +458,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "User is inactive",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "422": {
                         "description": "Validation error",
                         "schema": {
@@ -439,33 +653,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/otp/resend": {
-            "post": {
-                "description": "Resend one-time password to the user",
-                "consumes": [
-                    "application/json"
-                ],
+        "/countries": {
+            "get": {
+                "description": "List countries sorted by name, names are localized using Accept-Language",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OTP"
+                    "Country"
                 ],
-                "summary": "Resend OTP",
+                "summary": "List countries",
                 "parameters": [
                     {
-                        "description": "OTP resend request data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.OtpResendRequest"
-                        }
+                        "type": "string",
+                        "example": "id, en;q=0.8",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Region, e.g. asia or north_america",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calling code prefix, e.g. 62 or +1",
+                        "name": "calling_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name prefix",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. name,alpha2",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP resent successfully",
+                        "description": "Countries",
                         "schema": {
                             "allOf": [
                                 {
@@ -475,7 +708,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OtpResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CountryResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -483,25 +719,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Validation error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "429": {
-                        "description": "Too many requests - rate limited",
+                        "description": "Invalid field",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -515,33 +733,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/otp/validate": {
-            "post": {
-                "description": "Validate one-time password provided by the user",
-                "consumes": [
-                    "application/json"
-                ],
+        "/countries/{code}": {
+            "get": {
+                "description": "Get a country by its ISO 3166-1 alpha-2, alpha-3 or numeric code, the name is localized using Accept-Language",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OTP"
+                    "Country"
                 ],
-                "summary": "Validate OTP",
+                "summary": "Get country",
                 "parameters": [
                     {
-                        "description": "OTP validation data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.OtpRequest"
-                        }
+                        "type": "string",
+                        "example": "id, en;q=0.8",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alpha-2, alpha-3 or numeric code, e.g. ID, IDN or 360",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. name,alpha2",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP validated successfully",
+                        "description": "Country",
                         "schema": {
                             "allOf": [
                                 {
@@ -551,7 +777,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OtpValidationResponse"
+                                            "$ref": "#/definitions/dto.CountryResponse"
                                         }
                                     }
                                 }
@@ -559,31 +785,65 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid code or field",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Country not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Invalid OTP",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    },
-                    "422": {
-                        "description": "Validation error",
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the invitations created by the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "List invitations",
+                "responses": {
+                    "200": {
+                        "description": "Invitations",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.InvitationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "429": {
-                        "description": "Too many attempts",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -595,11 +855,589 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "dto.AuthResponse": {
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an invitation code, an invitation link is emailed when an email is provided",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "Create invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Invitation created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.InvitationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invite quota exceeded or invitations disabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already registered",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/otp/resend": {
+            "post": {
+                "description": "Resend one-time password to the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OTP"
+                ],
+                "summary": "Resend OTP",
+                "parameters": [
+                    {
+                        "description": "OTP resend request data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OtpResendRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OTP resent successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OtpResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/otp/validate": {
+            "post": {
+                "description": "Validate one-time password provided by the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OTP"
+                ],
+                "summary": "Validate OTP",
+                "parameters": [
+                    {
+                        "description": "OTP validation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OtpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OTP validated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OtpValidationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid OTP",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many attempts",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/activity": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the sign-ins, password changes and other events of the current user's account, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get activity",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page, defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Events per page, defaults to 20, at most 100",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account activity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginateResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/dto.AuditEventResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a new profile picture, the image is resized into small, medium and large sizes",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Upload avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Avatar image (jpeg, png, gif or webp)",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Avatar uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.AvatarResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid avatar file",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Avatar is too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported image type",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user preferences, unset preferences are returned with their default value",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get preferences",
+                "responses": {
+                    "200": {
+                        "description": "User preferences",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Partially update the current user preferences, omitted fields are left unchanged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preferences updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid preference value",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error, or an otp_channel that is not available yet",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AuditEventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "signed_in"
+                },
+                "actor_id": {
+                    "description": "The user who caused the event, empty for operators",
+                    "type": "integer"
+                },
+                "actor_type": {
+                    "description": "Who caused the event, user or operator",
+                    "type": "string",
+                    "example": "user"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-10-19T08:30:00Z"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "success or failure",
+                    "type": "string",
+                    "example": "success"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "The account the event is about, empty when it isn't known",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AuthResponse": {
             "type": "object",
             "properties": {
                 "access_token": {
@@ -624,6 +1462,130 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.AvatarResponse": {
+            "type": "object",
+            "properties": {
+                "large": {
+                    "description": "512x512 avatar\nexample: https://cdn.example.com/avatars/1/1700000000/large.jpg",
+                    "type": "string"
+                },
+                "medium": {
+                    "description": "256x256 avatar\nexample: https://cdn.example.com/avatars/1/1700000000/medium.jpg",
+                    "type": "string"
+                },
+                "small": {
+                    "description": "64x64 avatar\nexample: https://cdn.example.com/avatars/1/1700000000/small.jpg",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CountryResponse": {
+            "type": "object",
+            "properties": {
+                "alpha2": {
+                    "description": "ISO 3166-1 alpha-2 code\nexample: ID",
+                    "type": "string"
+                },
+                "alpha3": {
+                    "description": "ISO 3166-1 alpha-3 code\nexample: IDN",
+                    "type": "string"
+                },
+                "alpha_code": {
+                    "description": "ISO 3166-1 alpha-3 code, kept for backward compatibility\nexample: IDN",
+                    "type": "string"
+                },
+                "calling_code": {
+                    "description": "First calling code\nexample: 62",
+                    "type": "string"
+                },
+                "calling_codes": {
+                    "description": "Every calling code\nexample: [\"62\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "flags": {
+                    "$ref": "#/definitions/dto.Flag"
+                },
+                "name": {
+                    "description": "Country name in the language selected by Accept-Language\nexample: Indonesia",
+                    "type": "string"
+                },
+                "numeric": {
+                    "description": "ISO 3166-1 numeric code\nexample: 360",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "Region the country belongs to\nexample: Asia",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email to send the invitation to, the invitation can only be used by this email when set\nformat: email\nexample: friend@example.com",
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "Lifetime of the invitation in seconds, uses the configured default when empty\nexample: 604800",
+                    "type": "integer",
+                    "minimum": 60
+                },
+                "max_uses": {
+                    "description": "Number of sign-ups allowed with this invitation, uses the configured default when empty\nexample: 1",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.Flag": {
+            "type": "object",
+            "properties": {
+                "png": {
+                    "type": "string"
+                },
+                "svg": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Invitation code\nexample: MFRGGZDFMZTWQ2LK",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Creation time",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email the invitation is restricted to\nexample: friend@example.com",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Expiration time",
+                    "type": "string"
+                },
+                "link": {
+                    "description": "Sign-up link containing the invitation code\nexample: https://app.example.com/sign-up?invite=MFRGGZDFMZTWQ2LK",
+                    "type": "string"
+                },
+                "max_uses": {
+                    "description": "Number of sign-ups allowed\nexample: 1",
+                    "type": "integer"
+                },
+                "used_count": {
+                    "description": "Number of sign-ups already done with this invitation\nexample: 0",
+                    "type": "integer"
+                }
+            }
+        },
         "dto.OtpRequest": {
             "type": "object",
             "required": [
@@ -638,9 +1600,9 @@ const docTemplate = `{
                 },
                 "otp": {
                     "description": "OTP Number (6 digits)\nrequired: true\nminimum: 100000\nmaximum: 999999\nexample: 123456",
-                    "type": "integer",
-                    "maximum": 999999,
-                    "minimum": 0
+                    "type": "string",
+                    "maxLength": 999999,
+                    "minLength": 0
                 },
                 "type": {
                     "description": "Type of OTP request, e.g. signup, reset_password\nrequired: true\nenum: signup,request_reset\nexample: request_reset",
@@ -711,6 +1673,39 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "description": "BCP 47 language tag\nexample: en-US",
+                    "type": "string"
+                },
+                "marketing_emails": {
+                    "description": "Whether the user accepts marketing emails\nexample: false",
+                    "type": "boolean"
+                },
+                "otp_channel": {
+                    "description": "Preferred channel to receive OTP codes\nexample: email",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA time zone name\nexample: Asia/Jakarta",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReportActivityRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "Token from the \"this wasn't me\" link of a security notification email",
+                    "type": "string"
+                }
+            }
+        },
         "dto.RequestResetRequest": {
             "type": "object",
             "required": [
@@ -752,16 +1747,14 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "password": {
-                    "description": "Password is the new password the user wants to set.\nrequired: true\nmin length: 0\nmax length: 8",
+                    "description": "Password is the new password the user wants to set.\nrequired: true\nmin length: 3",
                     "type": "string",
-                    "maxLength": 8,
-                    "minLength": 0
+                    "minLength": 3
                 },
                 "password_confirmation": {
-                    "description": "PasswordConfirmation must match the password field.\nrequired: true\nmin length: 0\nmax length: 8",
+                    "description": "PasswordConfirmation must match the password field.\nrequired: true\nmin length: 3",
                     "type": "string",
-                    "maxLength": 8,
-                    "minLength": 0
+                    "minLength": 3
                 }
             }
         },
@@ -792,16 +1785,26 @@ const docTemplate = `{
                 "username"
             ],
             "properties": {
+                "country_code": {
+                    "description": "Country of the phone number as ISO 3166-1 alpha-2, alpha-3 or numeric code (optional)\nexample: ID",
+                    "type": "string",
+                    "maxLength": 3,
+                    "minLength": 2
+                },
                 "email": {
                     "description": "Email address (required)\nrequired: true\nformat: email\nexample: john.doe@example.com",
                     "type": "string"
                 },
+                "invite_code": {
+                    "description": "Invitation code, required when registration is invite only\nexample: MFRGGZDFMZTWQ2LK",
+                    "type": "string"
+                },
                 "password": {
                     "description": "Password (required)\nrequired: true\nmin length: 8\nmax length: 100\nexample: SecurePass123!",
                     "type": "string"
                 },
                 "phone_number": {
-                    "description": "Phone number (optional)\npattern: ^\\+?[1-9]\\d{1,14}$\nexample: +1234567890",
+                    "description": "Phone number (optional), numbers without a country calling code are parsed using country_code\nexample: 0812-3456-7890",
                     "type": "string"
                 },
                 "username": {
@@ -812,6 +1815,31 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UpdatePreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "description": "BCP 47 language tag\nexample: id-ID",
+                    "type": "string"
+                },
+                "marketing_emails": {
+                    "description": "Whether the user accepts marketing emails\nexample: true",
+                    "type": "boolean"
+                },
+                "otp_channel": {
+                    "description": "Preferred channel to receive OTP codes, sms is rejected with otp_channel_unavailable until it is supported\nenum: email,sms\nexample: email",
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "sms"
+                    ]
+                },
+                "timezone": {
+                    "description": "IANA time zone name\nexample: Asia/Jakarta",
+                    "type": "string"
+                }
+            }
+        },
         "dto.VerifyUserRequest": {
             "type": "object",
             "required": [
@@ -853,6 +1881,30 @@ const docTemplate = `{
                 }
             }
         },
+        "response.PaginateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "The paginated data"
+                },
+                "page": {
+                    "description": "Current page number",
+                    "type": "integer"
+                },
+                "per_page": {
+                    "description": "Number of items per page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total number of items available",
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "description": "Total number of pages",
+                    "type": "integer"
+                }
+            }
+        },
         "response.Response": {
             "type": "object",
             "properties": {
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
 )
@@ -79,3 +80,21 @@ func GetFirstElement(codes []string) string {
 	}
 	return ""
 }
+
+// Truncate shortens value to at most length characters, cutting on rune boundaries so the result stays valid UTF-8
+func Truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+
+	runes := 0
+	for i := range value {
+		if runes == length {
+			return value[:i]
+		}
+
+		runes++
+	}
+
+	return value
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/infrastructure/database"
+	redisInfra "github.com/winartodev/apollo-be/infrastructure/redis"
+	"github.com/winartodev/apollo-be/internal/domain"
+	"github.com/winartodev/apollo-be/internal/domain/entities"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+)
+
+const (
+	apiKeyPrefix      = "apk_"
+	apiKeyRandomBytes = 32
+	apiKeyPrefixSize  = len(apiKeyPrefix) + 8
+
+	apiKeyRedisKey = "api_key:%s"
+	apiKeyCacheTTL = time.Minute
+
+	createAPIKeyQuery = `
+		INSERT INTO api_keys (name, prefix, key_hash, created_at)
+		VALUES ($1, $2, $3, $4) RETURNING id
+	`
+
+	revokeAPIKeyQuery = `
+		UPDATE api_keys
+			SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING key_hash
+	`
+
+	getAPIKeyStatusQuery = `
+		SELECT ak.revoked_at IS NULL
+		FROM api_keys AS ak
+		WHERE ak.key_hash = $1
+	`
+)
+
+type apiKeyStatus struct {
+	IsValid bool `json:"is_valid"`
+}
+
+type APIKeyService struct {
+	db    *database.Database
+	redis *redisInfra.Redis
+}
+
+func NewAPIKeyService(db *database.Database, redis *redisInfra.Redis) domain.APIKeyService {
+	return &APIKeyService{
+		db:    db,
+		redis: redis,
+	}
+}
+
+// CreateAPIKey implements domain.APIKeyService.
+// The key is only returned here, the database keeps its SHA-256 hash.
+func (aks *APIKeyService) CreateAPIKey(ctx context.Context, name string) (key string, res *entities.APIKey, err error) {
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, err
+	}
+
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+	res = &entities.APIKey{
+		Name:      name,
+		Prefix:    key[:apiKeyPrefixSize],
+		CreatedAt: time.Now(),
+	}
+
+	err = aks.db.DB.QueryRowContext(ctx, createAPIKeyQuery, res.Name, res.Prefix, hashAPIKey(key), res.CreatedAt).Scan(&res.ID)
+	if err != nil {
+		return "", nil, domainError.ErrFailedCreateAPIKey
+	}
+
+	return key, res, nil
+}
+
+// RevokeAPIKey implements domain.APIKeyService.
+func (aks *APIKeyService) RevokeAPIKey(ctx context.Context, id int64) error {
+	var keyHash string
+	err := aks.db.DB.QueryRowContext(ctx, revokeAPIKeyQuery, id, time.Now()).Scan(&keyHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainError.ErrAPIKeyNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return aks.redis.Delete(ctx, fmt.Sprintf(apiKeyRedisKey, keyHash))
+}
+
+// VerifyAPIKey implements domain.APIKeyService.
+// The status of a known key is cached briefly so API key requests don't hit the database every time.
+func (aks *APIKeyService) VerifyAPIKey(ctx context.Context, key string) (bool, error) {
+	if key == "" {
+		return false, nil
+	}
+
+	keyHash := hashAPIKey(key)
+	cacheKey := fmt.Sprintf(apiKeyRedisKey, keyHash)
+
+	var cached apiKeyStatus
+	err := aks.redis.Get(ctx, cacheKey, &cached)
+	if err == nil {
+		return cached.IsValid, nil
+	}
+
+	if !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+
+	// Unknown keys are not cached, random keys would fill Redis with entries that are never asked for again
+	var isValid bool
+	err = aks.db.DB.QueryRowContext(ctx, getAPIKeyStatusQuery, keyHash).Scan(&isValid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	status := apiKeyStatus{IsValid: isValid}
+	if err := aks.redis.SetEx(ctx, cacheKey, status, apiKeyCacheTTL); err != nil {
+		return false, err
+	}
+
+	return status.IsValid, nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,9 +1,9 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -23,29 +23,23 @@ var (
 )
 
 type UserJWT struct {
-	ID       int64  `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	ID           int64  `json:"id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	TokenVersion int64  `json:"token_version"`
 }
 
 type JWTClaims struct {
-	ID       int64  `json:"id,omitempty"`
-	Username string `json:"username,omitempty"`
-	Email    string `json:"email,omitempty"`
+	ID           int64  `json:"id,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Email        string `json:"email,omitempty"`
+	TokenVersion int64  `json:"ver,omitempty"`
 	jwt.StandardClaims
 }
 
-type accessToken struct {
-	SecretKey []byte
-}
-
-type refreshToken struct {
-	SecretKey []byte
-}
-
+// JWT signs and verifies tokens with the secrets of the current configuration, so rotated secrets are picked up
 type JWT struct {
-	AccessToken  accessToken
-	RefreshToken refreshToken
+	config *config.Jwt
 }
 
 type JWTResponse struct {
@@ -53,58 +47,83 @@ type JWTResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-func NewJWT() (*JWT, error) {
-	atSecret := os.Getenv(config.JwtAccessTokenSecretKey)
-	if atSecret == "" {
+func NewJWT(jwtConfig *config.Jwt) (*JWT, error) {
+	if jwtConfig.AccessTokenSecret == "" {
 		return nil, errors.New("access token secret key is empty")
 	}
 
-	rtSecret := os.Getenv(config.JwtRefreshTokenSecretKey)
-	if rtSecret == "" {
+	if jwtConfig.RefreshTokenSecret == "" {
 		return nil, errors.New("refresh token secret key is empty")
 	}
 
 	return &JWT{
-		AccessToken: accessToken{
-			SecretKey: []byte(atSecret),
-		},
-		RefreshToken: refreshToken{
-			SecretKey: []byte(rtSecret),
-		},
+		config: jwtConfig,
 	}, nil
 }
 
+// AccessTokenSecret returns the current access token signing key
+func (j *JWT) AccessTokenSecret() ([]byte, error) {
+	secret, err := j.config.GetAccessTokenSecret(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(secret), nil
+}
+
+// RefreshTokenSecret returns the current refresh token signing key
+func (j *JWT) RefreshTokenSecret() ([]byte, error) {
+	secret, err := j.config.GetRefreshTokenSecret(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(secret), nil
+}
+
 func (j *JWT) GenerateToken(user *UserJWT) (result *JWTResponse, err error) {
 	if user == nil {
 		return nil, errors.New("user not found")
 	}
 
-	if !isSecretKeyExists(j.AccessToken.SecretKey) && !isSecretKeyExists(j.RefreshToken.SecretKey) {
+	accessTokenSecret, err := j.AccessTokenSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTokenSecret, err := j.RefreshTokenSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSecretKeyExists(accessTokenSecret) && !isSecretKeyExists(refreshTokenSecret) {
 		return nil, errorMissingSecretKey
 	}
 
 	newAccessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
-		ID:       user.ID,
-		Username: user.Username,
-		Email:    user.Email,
+		ID:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		TokenVersion: user.TokenVersion,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(15 * time.Minute).Unix(),
 		},
 	})
 
 	newRefreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
-		ID: user.ID,
+		ID:           user.ID,
+		TokenVersion: user.TokenVersion,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(24 * time.Hour).Unix(),
 		},
 	})
 
-	newAccessTokenString, err := newAccessToken.SignedString(j.AccessToken.SecretKey)
+	newAccessTokenString, err := newAccessToken.SignedString(accessTokenSecret)
 	if err != nil {
 		return nil, err
 	}
 
-	newRefreshTokenString, err := newRefreshToken.SignedString(j.RefreshToken.SecretKey)
+	newRefreshTokenString, err := newRefreshToken.SignedString(refreshTokenSecret)
 	if err != nil {
 		return nil, err
 	}
@@ -22,9 +22,10 @@ func NewJwtTokenService(jwt *JWT) domain.TokenService {
 // GenerateTokenPair implements domain.TokenService.
 func (jts *JwtTokenService) GenerateTokenPair(user *domainEntity.SharedUser) (*domain.TokenPair, error) {
 	userJWT := &UserJWT{
-		ID:       user.ID,
-		Username: user.Username,
-		Email:    user.Email,
+		ID:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		TokenVersion: user.TokenVersion,
 	}
 
 	tokenPair, err := jts.jwt.GenerateToken(userJWT)
@@ -46,7 +47,12 @@ func (jts *JwtTokenService) InvalidateToken(token string) error {
 
 // ValidateAccessToken implements domain.TokenService.
 func (jts *JwtTokenService) ValidateAccessToken(token string) (*domain.TokenClaims, error) {
-	claims, isValid, err := jts.jwt.VerifyToken(jts.jwt.AccessToken.SecretKey, token)
+	secretKey, err := jts.jwt.AccessTokenSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify access token: %v", err)
+	}
+
+	claims, isValid, err := jts.jwt.VerifyToken(secretKey, token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify access token: %v", err)
 	}
@@ -60,7 +66,12 @@ func (jts *JwtTokenService) ValidateAccessToken(token string) (*domain.TokenClai
 
 // ValidateRefreshToken implements domain.TokenService.
 func (jts *JwtTokenService) ValidateRefreshToken(token string) (*domain.TokenClaims, error) {
-	claims, isValid, err := jts.jwt.VerifyToken(jts.jwt.RefreshToken.SecretKey, token)
+	secretKey, err := jts.jwt.RefreshTokenSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify refresh token: %v", err)
+	}
+
+	claims, isValid, err := jts.jwt.VerifyToken(secretKey, token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify refresh token: %v", err)
 	}
@@ -86,6 +97,10 @@ func (jts *JwtTokenService) claimsToTokenClaims(claims map[string]interface{}) (
 		tokenClaims.Email = email
 	}
 
+	if version, ok := claims["ver"].(float64); ok {
+		tokenClaims.TokenVersion = int64(version)
+	}
+
 	if issueAt, ok := claims["issueAt"].(float64); ok {
 		tokenClaims.IssueAt = time.Unix(int64(issueAt), 0)
 	}
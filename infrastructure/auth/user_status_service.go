@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/infrastructure/database"
+	redisInfra "github.com/winartodev/apollo-be/infrastructure/redis"
+	"github.com/winartodev/apollo-be/internal/domain"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+)
+
+const (
+	userStatusRedisKey = "user_status:%d"
+	userStatusCacheTTL = time.Minute
+
+	getUserStatusQuery = `
+		SELECT
+		    usr.is_active,
+		    usr.deleted_at IS NULL,
+		    usr.is_admin,
+		    usr.token_version
+		FROM users AS usr
+		WHERE usr.id = $1
+	`
+)
+
+type userStatus struct {
+	IsActive     bool  `json:"is_active"`
+	IsAdmin      bool  `json:"is_admin"`
+	TokenVersion int64 `json:"token_version"`
+}
+
+type UserStatusService struct {
+	db    *database.Database
+	redis *redisInfra.Redis
+}
+
+func NewUserStatusService(db *database.Database, redis *redisInfra.Redis) domain.UserStatusService {
+	return &UserStatusService{
+		db:    db,
+		redis: redis,
+	}
+}
+
+// IsUserAdmin implements domain.UserStatusService, an inactive user is never an admin
+func (uss *UserStatusService) IsUserAdmin(ctx context.Context, userID int64) (bool, error) {
+	status, err := uss.getUserStatus(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return status.IsActive && status.IsAdmin, nil
+}
+
+// AuthorizeToken implements domain.UserStatusService.
+// The status is cached briefly so authenticated requests don't hit the database every time.
+func (uss *UserStatusService) AuthorizeToken(ctx context.Context, userID int64, tokenVersion int64) error {
+	status, err := uss.getUserStatus(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !status.IsActive {
+		return domainError.ErrUserInactive
+	}
+
+	if tokenVersion != status.TokenVersion {
+		return domainError.ErrTokenRevoked
+	}
+
+	return nil
+}
+
+// GetTokenVersion implements domain.UserStatusService.
+func (uss *UserStatusService) GetTokenVersion(ctx context.Context, userID int64) (int64, error) {
+	status, err := uss.getUserStatus(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return status.TokenVersion, nil
+}
+
+func (uss *UserStatusService) getUserStatus(ctx context.Context, userID int64) (*userStatus, error) {
+	key := fmt.Sprintf(userStatusRedisKey, userID)
+
+	var cached userStatus
+	err := uss.redis.Get(ctx, key, &cached)
+	if err == nil {
+		return &cached, nil
+	}
+
+	if !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	var isActive, isNotDeleted, isAdmin bool
+	var tokenVersion int64
+	err = uss.db.DB.QueryRowContext(ctx, getUserStatusQuery, userID).Scan(&isActive, &isNotDeleted, &isAdmin, &tokenVersion)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &userStatus{}, nil
+		}
+
+		return nil, domainError.ErrFailedGetUserData
+	}
+
+	status := userStatus{IsActive: isActive && isNotDeleted, IsAdmin: isAdmin, TokenVersion: tokenVersion}
+	if err := uss.redis.SetEx(ctx, key, status, userStatusCacheTTL); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// InvalidateUserStatus implements domain.UserStatusService.
+func (uss *UserStatusService) InvalidateUserStatus(ctx context.Context, userID int64) error {
+	return uss.redis.Delete(ctx, fmt.Sprintf(userStatusRedisKey, userID))
+}
@@ -10,6 +10,11 @@ type ContextKey string
 const (
 	UserIdKey      ContextKey = "user_id"
 	AppPlatformKey ContextKey = "application_platform"
+	RequestIdKey   ContextKey = "request_id"
+
+	AcceptLanguageKey ContextKey = "accept_language"
+	ClientIPKey       ContextKey = "client_ip"
+	UserAgentKey      ContextKey = "user_agent"
 )
 
 var (
@@ -18,6 +23,9 @@ var (
 
 	errAppPlatformNotFound = errors.New("app platform not found in context")
 	errInvalidAppPlatform  = errors.New("invalid app platform")
+
+	errRequestIDNotFound = errors.New("request ID not found in context")
+	errInvalidRequestID  = errors.New("invalid request ID")
 )
 
 func GetUserIDFromContext(ctx context.Context) (int64, error) {
@@ -47,3 +55,35 @@ func GetAppPlatformFromContext(ctx context.Context) (string, error) {
 
 	return appPlatform, nil
 }
+
+func GetRequestIDFromContext(ctx context.Context) (string, error) {
+	value := ctx.Value(RequestIdKey)
+	if value == nil {
+		return "", errRequestIDNotFound
+	}
+
+	requestID, ok := value.(string)
+	if !ok {
+		return "", errInvalidRequestID
+	}
+
+	return requestID, nil
+}
+
+// GetAcceptLanguageFromContext returns the request's Accept-Language header, empty when there is none
+func GetAcceptLanguageFromContext(ctx context.Context) string {
+	acceptLanguage, _ := ctx.Value(AcceptLanguageKey).(string)
+	return acceptLanguage
+}
+
+// GetClientIPFromContext returns the client's IP address, empty outside a request
+func GetClientIPFromContext(ctx context.Context) string {
+	clientIP, _ := ctx.Value(ClientIPKey).(string)
+	return clientIP
+}
+
+// GetUserAgentFromContext returns the request's User-Agent header, empty when there is none
+func GetUserAgentFromContext(ctx context.Context) string {
+	userAgent, _ := ctx.Value(UserAgentKey).(string)
+	return userAgent
+}
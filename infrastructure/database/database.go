@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"time"
 )
 
 const (
@@ -30,3 +31,21 @@ func (d *Database) CloseStatement(stmt *sql.Stmt, errPtr *error) {
 		}
 	}
 }
+
+// TimePtr returns nil for a NULL timestamp column
+func TimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+// Int64Ptr returns nil for a NULL integer column
+func Int64Ptr(i sql.NullInt64) *int64 {
+	if !i.Valid {
+		return nil
+	}
+
+	return &i.Int64
+}
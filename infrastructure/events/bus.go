@@ -0,0 +1,95 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	"github.com/winartodev/apollo-be/internal/domain"
+)
+
+// Handler handles an account event, an error fails the publisher
+type Handler func(ctx context.Context, event domain.AccountEvent) error
+
+// Bus is an in-process EventPublisher, subscribers run synchronously in the order they subscribed
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[domain.AccountEventType][]Handler
+	all      []Handler
+}
+
+// NewBus creates a new bus without subscribers
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[domain.AccountEventType][]Handler),
+	}
+}
+
+// Subscribe registers the handler for the event types, or for every event when no type is given
+func (b *Bus) Subscribe(handler Handler, types ...domain.AccountEventType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(types) == 0 {
+		b.all = append(b.all, handler)
+		return
+	}
+
+	for _, eventType := range types {
+		b.handlers[eventType] = append(b.handlers[eventType], handler)
+	}
+}
+
+// Publish fills in the defaults, the time and the request's details when they are missing, then runs every subscriber
+func (b *Bus) Publish(ctx context.Context, event domain.AccountEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	if event.Result == "" {
+		event.Result = domain.EventSucceeded
+	}
+
+	if event.Actor == "" {
+		event.Actor = domain.ActorUser
+	}
+
+	if event.Actor == domain.ActorUser && event.ActorID == 0 {
+		event.ActorID = event.UserID
+		if userID, err := infraContext.GetUserIDFromContext(ctx); err == nil {
+			event.ActorID = userID
+		}
+	}
+
+	if event.RequestID == "" {
+		event.RequestID, _ = infraContext.GetRequestIDFromContext(ctx)
+	}
+
+	if event.IP == "" {
+		event.IP = infraContext.GetClientIPFromContext(ctx)
+	}
+
+	if event.UserAgent == "" {
+		event.UserAgent = infraContext.GetUserAgentFromContext(ctx)
+	}
+
+	b.mu.RLock()
+	handlers := append(append([]Handler{}, b.all...), b.handlers[event.Type]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to handle %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
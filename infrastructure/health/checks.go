@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/config"
+)
+
+// DatabaseCheck runs a query on a pooled connection
+func DatabaseCheck(db *sql.DB) Check {
+	return Check{
+		Name: "database",
+		Func: func(ctx context.Context) error {
+			return config.ValidateConnectionPool(ctx, db)
+		},
+	}
+}
+
+// RedisCheck pings Redis
+func RedisCheck(client *redis.Client) Check {
+	return Check{
+		Name: "redis",
+		Func: func(ctx context.Context) error {
+			return client.Ping(ctx).Err()
+		},
+	}
+}
+
+// SMTPCheck only dials the server, it doesn't authenticate or send anything
+func SMTPCheck(smtpConfig *config.SMTPConfig) Check {
+	return Check{
+		Name: "smtp",
+		Func: func(ctx context.Context) error {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(smtpConfig.Host, fmt.Sprint(smtpConfig.Port)))
+			if err != nil {
+				return err
+			}
+
+			return conn.Close()
+		},
+	}
+}
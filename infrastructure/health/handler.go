@@ -0,0 +1,35 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/winartodev/apollo-be/infrastructure/http/response"
+)
+
+// RegisterRoutes serves the probes at the root, outside /api. The older /api/health-check is kept for
+// existing monitors and reports readiness.
+func (hc *Checker) RegisterRoutes(e *echo.Echo) {
+	e.GET("/livez", hc.Livez)
+	e.GET("/readyz", hc.Readyz)
+	e.GET("/api/health-check", hc.Readyz)
+}
+
+// Livez reports that the process is running, dependencies are not checked so a slow database never restarts it
+func (hc *Checker) Livez(c echo.Context) error {
+	return response.SuccessResponse(c, http.StatusOK, "OK", nil, nil)
+}
+
+// Readyz reports the status and latency of every dependency, 503 when one is down or the server is shutting down
+func (hc *Checker) Readyz(c echo.Context) error {
+	report := hc.Ready(c.Request().Context())
+	if !report.IsReady() {
+		return c.JSON(http.StatusServiceUnavailable, response.Response{
+			Success: false,
+			Message: "not ready",
+			Data:    report,
+		})
+	}
+
+	return response.SuccessResponse(c, http.StatusOK, "OK", report, nil)
+}
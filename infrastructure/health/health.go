@@ -0,0 +1,153 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+)
+
+const (
+	StatusUp           = "up"
+	StatusDown         = "down"
+	StatusShuttingDown = "shutting_down"
+)
+
+// CheckFunc reports whether a dependency is usable, it must return once ctx is done
+type CheckFunc func(ctx context.Context) error
+
+// Check is a named readiness check
+type Check struct {
+	Name string
+	Func CheckFunc
+}
+
+// CheckResult is the outcome of one check
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the readiness of the service and of every dependency
+type Report struct {
+	Status    string                 `json:"status"`
+	CheckedAt time.Time              `json:"checked_at"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// IsReady reports whether every check passed
+func (r *Report) IsReady() bool {
+	return r.Status == StatusUp
+}
+
+// Checker runs the readiness checks concurrently, each bounded by the configured timeout.
+// The last report is reused for the cache TTL so frequent probes don't add load to the dependencies.
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	shuttingDown atomic.Bool
+
+	mu     sync.Mutex
+	cached *Report
+}
+
+// NewChecker creates a checker for the given checks
+func NewChecker(cfg *config.Health, checks ...Check) *Checker {
+	return &Checker{
+		checks:   checks,
+		timeout:  cfg.GetTimeout(),
+		cacheTTL: cfg.GetCacheTTL(),
+	}
+}
+
+// SetShuttingDown makes the service report not ready so load balancers stop routing to it while it drains
+func (hc *Checker) SetShuttingDown() {
+	hc.shuttingDown.Store(true)
+}
+
+// Ready returns the cached report or runs the checks when it has expired.
+// Concurrent callers wait for the running checks instead of starting their own.
+func (hc *Checker) Ready(ctx context.Context) Report {
+	if hc.shuttingDown.Load() {
+		return Report{Status: StatusShuttingDown, CheckedAt: time.Now().UTC()}
+	}
+
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if hc.cached != nil && time.Since(hc.cached.CheckedAt) < hc.cacheTTL {
+		return *hc.cached
+	}
+
+	report := hc.run(ctx)
+	hc.cached = &report
+
+	return report
+}
+
+func (hc *Checker) run(ctx context.Context) Report {
+	results := make([]CheckResult, len(hc.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range hc.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = hc.runCheck(ctx, check)
+		}()
+	}
+
+	wg.Wait()
+
+	report := Report{
+		Status:    StatusUp,
+		CheckedAt: time.Now().UTC(),
+		Checks:    make(map[string]CheckResult, len(hc.checks)),
+	}
+
+	for i, check := range hc.checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+// runCheck stops waiting at the timeout even when the check ignores ctx
+func (hc *Checker) runCheck(ctx context.Context, check Check) CheckResult {
+	// The probe request may be cancelled, the shared result must not be
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), hc.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Func(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
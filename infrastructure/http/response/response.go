@@ -136,6 +136,10 @@ func getValidationErrorMessage(fe validator.FieldError) string {
 		return "This field must contain only letters"
 	case "alphanum":
 		return "This field must contain only letters and numbers"
+	case "e164":
+		return "This field must be a phone number in E.164 format, e.g. +6281234567890"
+	case "phone":
+		return "Invalid phone number"
 	default:
 		return "Invalid value"
 	}
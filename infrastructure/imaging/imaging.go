@@ -0,0 +1,105 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+	ContentTypeWEBP = "image/webp"
+
+	sniffLength = 512
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image type")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+
+	supportedContentTypes = map[string]struct{}{
+		ContentTypeJPEG: {},
+		ContentTypePNG:  {},
+		ContentTypeGIF:  {},
+		ContentTypeWEBP: {},
+	}
+)
+
+// DetectContentType sniffs the content type from the data instead of trusting the client header
+func DetectContentType(data []byte) (string, error) {
+	if len(data) > sniffLength {
+		data = data[:sniffLength]
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := supportedContentTypes[contentType]; !ok {
+		return "", ErrUnsupportedImage
+	}
+
+	return contentType, nil
+}
+
+// Decode decodes the image after checking its dimensions and pixel count, so oversized images
+// are rejected before their pixels are allocated
+func Decode(data []byte, maxDimension int, maxPixels int) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("%w: empty image", ErrUnsupportedImage)
+	}
+
+	// Both sides are bounded first so the product can't overflow
+	if cfg.Width > maxDimension || cfg.Height > maxDimension || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	return img, nil
+}
+
+// ResizeSquare crops the center square of the image and scales it to size x size
+func ResizeSquare(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+	crop := image.Rect(x0, y0, x0+side, y0+side)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	// Fill with white so transparent areas don't turn black once encoded as JPEG
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Over, nil)
+
+	return dst
+}
+
+// EncodeJPEG re-encodes the image as JPEG. The encoder never writes metadata,
+// so EXIF data (location, device, etc.) from the original upload is stripped
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode jpeg: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/winartodev/apollo-be/config"
+	customContext "github.com/winartodev/apollo-be/infrastructure/context"
+)
+
+// handler filters records by package level and adds the request fields from the context
+type handler struct {
+	next     slog.Handler
+	level    slog.Level
+	fallback slog.Level
+	packages map[string]slog.Level
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID, err := customContext.GetRequestIDFromContext(ctx); err == nil && requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+
+	if userID, err := customContext.GetUserIDFromContext(ctx); err == nil {
+		record.AddAttrs(slog.Int64("user_id", userID))
+	}
+
+	if platform, err := customContext.GetAppPlatformFromContext(ctx); err == nil && platform != "" {
+		record.AddAttrs(slog.String("platform", platform))
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs switches to the package level when the package attribute is set
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	level := h.level
+	for _, attr := range attrs {
+		if attr.Key != config.LogPackageKey {
+			continue
+		}
+
+		level = h.fallback
+		if packageLevel, ok := h.packages[attr.Value.String()]; ok {
+			level = packageLevel
+		}
+	}
+
+	return &handler{
+		next:     h.next.WithAttrs(attrs),
+		level:    level,
+		fallback: h.fallback,
+		packages: h.packages,
+	}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{
+		next:     h.next.WithGroup(name),
+		level:    h.level,
+		fallback: h.fallback,
+		packages: h.packages,
+	}
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/winartodev/apollo-be/config"
+)
+
+// Logger hands out slog loggers sharing one output. Every record carries the request ID, user ID and platform
+// found in its context, sensitive attributes are redacted and the level can be set per package.
+// Create it once per process and log with the *Context methods so the request fields are attached.
+type Logger struct {
+	handler *handler
+}
+
+func NewLogger(cfg *config.Logging, environment string) *Logger {
+	return newLogger(cfg, environment, os.Stdout)
+}
+
+func newLogger(cfg *config.Logging, environment string, w io.Writer) *Logger {
+	options := &slog.HandlerOptions{
+		// Levels are checked by handler, the output handler accepts everything it is given
+		Level:       slog.LevelDebug,
+		ReplaceAttr: redact,
+	}
+
+	var output slog.Handler
+	if cfg.GetFormat(environment) == config.LogFormatJSON {
+		output = slog.NewJSONHandler(w, options)
+	} else {
+		output = slog.NewTextHandler(w, options)
+	}
+
+	packages := make(map[string]slog.Level, len(cfg.Packages))
+	for name, level := range cfg.Packages {
+		packages[name] = parseLevel(level)
+	}
+
+	level := parseLevel(cfg.Level)
+	return &Logger{
+		handler: &handler{
+			next:     output,
+			level:    level,
+			fallback: level,
+			packages: packages,
+		},
+	}
+}
+
+// Named returns the logger of a package, its level is logging.packages[name] or logging.level
+func (l *Logger) Named(name string) *slog.Logger {
+	return slog.New(l.handler).With(config.LogPackageKey, name)
+}
+
+// SetDefault routes slog's default logger and the standard log package through this logger
+func (l *Logger) SetDefault() {
+	slog.SetDefault(slog.New(l.handler))
+}
+
+func parseLevel(level string) slog.Level {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+
+	return parsed
+}
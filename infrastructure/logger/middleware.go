@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Middleware logs one record per request, errors returned by handlers are logged at error level
+func (l *Logger) Middleware() echo.MiddlewareFunc {
+	logger := l.Named("http")
+
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		HandleError:  true,
+		LogMethod:    true,
+		LogURI:       true,
+		LogRoutePath: true,
+		LogStatus:    true,
+		LogLatency:   true,
+		LogRemoteIP:  true,
+		LogError:     true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			level := slog.LevelInfo
+			attrs := []slog.Attr{
+				slog.String("method", v.Method),
+				slog.String("uri", v.URI),
+				slog.String("route", v.RoutePath),
+				slog.Int("status", v.Status),
+				slog.Duration("latency", v.Latency),
+				slog.String("remote_ip", v.RemoteIP),
+			}
+
+			if v.Error != nil {
+				level = slog.LevelError
+				attrs = append(attrs, slog.Any("error", v.Error))
+			}
+
+			logger.LogAttrs(c.Request().Context(), level, "request", attrs...)
+			return nil
+		},
+	})
+}
+
+// Recover logs panics with their stack and turns them into 500 responses
+func (l *Logger) Recover() echo.MiddlewareFunc {
+	logger := l.Named("http")
+
+	return middleware.RecoverWithConfig(middleware.RecoverConfig{
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
+			logger.ErrorContext(c.Request().Context(), "panic recovered", slog.Any("error", err), slog.String("stack", string(stack)))
+			return err
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// secretKeys are attribute keys whose values never reach the logs
+var secretKeys = map[string]bool{
+	"password":      true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"authorization": true,
+	"otp":           true,
+	"api_key":       true,
+	"secret":        true,
+}
+
+// redact masks sensitive attributes by key: emails keep their first letter and domain, secrets are replaced
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+
+	switch {
+	case secretKeys[key]:
+		return slog.String(attr.Key, redacted)
+	case key == "email" || strings.HasSuffix(key, "_email"):
+		return slog.String(attr.Key, maskEmail(attr.Value.String()))
+	}
+
+	return attr
+}
+
+// maskEmail turns jane.doe@example.com into j***@example.com
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return redacted
+	}
+
+	return email[:1] + "***" + email[at:]
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/internal/domain"
+)
+
+const namespace = "apollo"
+
+// Metrics implements domain.Metrics with Prometheus and owns the registry served on /metrics.
+// Create it once per process, collectors can't be registered twice.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	signUps        prometheus.Counter
+	signInFailures *prometheus.CounterVec
+	otpSent        *prometheus.CounterVec
+	otpValidated   *prometheus.CounterVec
+	tokenRefreshes prometheus.Counter
+}
+
+var _ domain.Metrics = (*Metrics)(nil)
+
+func NewMetrics(db *sql.DB, databaseName string, redisClient *redis.Client) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		signUps: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "sign_ups_total",
+			Help:      "Completed sign-ups.",
+		}),
+		signInFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "sign_in_failures_total",
+			Help:      "Failed sign-ins by reason.",
+		}, []string{"reason"}),
+		otpSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "otp",
+			Name:      "sent_total",
+			Help:      "OTPs sent by delivery method.",
+		}, []string{"method"}),
+		otpValidated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "otp",
+			Name:      "validated_total",
+			Help:      "OTP validations by result.",
+		}, []string{"result"}),
+		tokenRefreshes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "token_refreshes_total",
+			Help:      "Issued token refreshes.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, databaseName),
+		newRedisCollector(redisClient),
+		m.httpRequests,
+		m.httpDuration,
+		m.signUps,
+		m.signInFailures,
+		m.otpSent,
+		m.otpValidated,
+		m.tokenRefreshes,
+	)
+
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// SignUp implements domain.Metrics.
+func (m *Metrics) SignUp() {
+	m.signUps.Inc()
+}
+
+// SignInFailed implements domain.Metrics.
+func (m *Metrics) SignInFailed(reason string) {
+	m.signInFailures.WithLabelValues(reason).Inc()
+}
+
+// OtpSent implements domain.Metrics.
+func (m *Metrics) OtpSent(method string) {
+	m.otpSent.WithLabelValues(method).Inc()
+}
+
+// OtpValidated implements domain.Metrics.
+func (m *Metrics) OtpValidated(valid bool) {
+	result := "invalid"
+	if valid {
+		result = "valid"
+	}
+
+	m.otpValidated.WithLabelValues(result).Inc()
+}
+
+// TokenRefreshed implements domain.Metrics.
+func (m *Metrics) TokenRefreshed() {
+	m.tokenRefreshes.Inc()
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels requests that matched no route, so scanners can't blow up the label cardinality
+const unmatchedRoute = "unmatched"
+
+// Middleware records the count and latency of every request labeled by the route template, e.g. /api/users/:id
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				// Let echo write the error response now so the recorded status is the one sent
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			method := c.Request().Method
+			status := strconv.Itoa(c.Response().Status)
+
+			m.httpRequests.WithLabelValues(method, route, status).Inc()
+			m.httpDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+
+			return nil
+		}
+	}
+}
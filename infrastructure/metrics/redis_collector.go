@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCollector exposes the go-redis connection pool statistics
+type redisCollector struct {
+	client *redis.Client
+
+	hits       *prometheus.Desc
+	misses     *prometheus.Desc
+	timeouts   *prometheus.Desc
+	totalConns *prometheus.Desc
+	idleConns  *prometheus.Desc
+	staleConns *prometheus.Desc
+}
+
+func newRedisCollector(client *redis.Client) prometheus.Collector {
+	return &redisCollector{
+		client:     client,
+		hits:       prometheus.NewDesc(namespace+"_redis_pool_hits_total", "Times a free connection was found in the pool.", nil, nil),
+		misses:     prometheus.NewDesc(namespace+"_redis_pool_misses_total", "Times a free connection was not found in the pool.", nil, nil),
+		timeouts:   prometheus.NewDesc(namespace+"_redis_pool_timeouts_total", "Times a wait for a connection timed out.", nil, nil),
+		totalConns: prometheus.NewDesc(namespace+"_redis_pool_connections", "Connections in the pool.", nil, nil),
+		idleConns:  prometheus.NewDesc(namespace+"_redis_pool_idle_connections", "Idle connections in the pool.", nil, nil),
+		staleConns: prometheus.NewDesc(namespace+"_redis_pool_stale_connections_total", "Stale connections removed from the pool.", nil, nil),
+	}
+}
+
+func (rc *redisCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rc.hits
+	ch <- rc.misses
+	ch <- rc.timeouts
+	ch <- rc.totalConns
+	ch <- rc.idleConns
+	ch <- rc.staleConns
+}
+
+func (rc *redisCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := rc.client.PoolStats()
+
+	ch <- prometheus.MustNewConstMetric(rc.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(rc.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(rc.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(rc.totalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(rc.idleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(rc.staleConns, prometheus.CounterValue, float64(stats.StaleConns))
+}
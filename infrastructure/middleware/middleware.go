@@ -8,17 +8,24 @@ import (
 	domainError "github.com/winartodev/apollo-be/internal/domain/error"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	customContext "github.com/winartodev/apollo-be/infrastructure/context"
 	"github.com/winartodev/apollo-be/infrastructure/http/response"
 	"github.com/winartodev/apollo-be/internal/domain"
 )
 
 type Middleware struct {
-	jwt domain.TokenService
+	jwt        domain.TokenService
+	userStatus domain.UserStatusService
+	apiKey     domain.APIKeyService
 }
 
-func NewMiddleware(jwt domain.TokenService) *Middleware {
-	return &Middleware{jwt: jwt}
+func NewMiddleware(jwt domain.TokenService, userStatus domain.UserStatusService, apiKey domain.APIKeyService) *Middleware {
+	return &Middleware{
+		jwt:        jwt,
+		userStatus: userStatus,
+		apiKey:     apiKey,
+	}
 }
 
 func (m *Middleware) HandleWithAuth() echo.MiddlewareFunc {
@@ -61,13 +68,59 @@ func (m *Middleware) HandleRefreshToken() echo.MiddlewareFunc {
 	}
 }
 
+// HandleWithAdmin is HandleWithAuth for routes only admins may use
+func (m *Middleware) HandleWithAdmin() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return m.HandleWithAuth()(func(c echo.Context) error {
+			ctx := c.Request().Context()
+			userID, err := customContext.GetUserIDFromContext(ctx)
+			if err != nil {
+				return response.FailedResponse(c, http.StatusUnauthorized, err)
+			}
+
+			isAdmin, err := m.userStatus.IsUserAdmin(ctx, userID)
+			if err != nil {
+				return response.FailedResponse(c, http.StatusInternalServerError, err)
+			}
+
+			if !isAdmin {
+				return response.FailedResponse(c, http.StatusForbidden, domainError.ErrAdminRequired)
+			}
+
+			return next(c)
+		})
+	}
+}
+
+// HandleWithAdminOrAPIKey is HandleWithAdmin for routes services may also call with a key from apollo apikey create,
+// requests sending X-API-Key are checked against the key instead of a bearer token
+func (m *Middleware) HandleWithAdminOrAPIKey() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withAPIKey := m.HandleWithAPIKey()(next)
+		withAdmin := m.HandleWithAdmin()(next)
+
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("X-API-Key") != "" {
+				return withAPIKey(c)
+			}
+
+			return withAdmin(c)
+		}
+	}
+}
+
 func (m *Middleware) HandleWithAPIKey() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			apiKey := c.Request().Header.Get("X-API-Key")
 
-			if m.verifyAPIKey(apiKey) {
-				return response.FailedResponse(c, http.StatusUnauthorized, domainError.ErrInvalidAuthorizationHeader)
+			isValid, err := m.apiKey.VerifyAPIKey(c.Request().Context(), apiKey)
+			if err != nil {
+				return response.FailedResponse(c, http.StatusInternalServerError, err)
+			}
+
+			if !isValid {
+				return response.FailedResponse(c, http.StatusUnauthorized, domainError.ErrInvalidAPIKey)
 			}
 
 			return next(c)
@@ -100,11 +153,12 @@ func (m *Middleware) verifyToken(ctx context.Context, authorization string, isAc
 		return nil, err
 	}
 
-	return claims, nil
-}
+	err = m.userStatus.AuthorizeToken(ctx, claims.UserID, claims.TokenVersion)
+	if err != nil {
+		return nil, err
+	}
 
-func (m *Middleware) verifyAPIKey(apiKey string) (isValid bool) {
-	return "hello" == apiKey
+	return claims, nil
 }
 
 func GetAppPlatform() echo.MiddlewareFunc {
@@ -123,3 +177,41 @@ func GetAppPlatform() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// RequestID sets X-Request-ID, keeping the one sent by the client, and stores it in the request context for logging
+func RequestID() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, requestID string) {
+			ctx := context.WithValue(c.Request().Context(), customContext.RequestIdKey, requestID)
+			c.SetRequest(c.Request().WithContext(ctx))
+		},
+	})
+}
+
+// AcceptLanguage stores the Accept-Language header in the request context, emails are localized with it
+// when the user has no locale preference
+func AcceptLanguage() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if acceptLanguage := c.Request().Header.Get("Accept-Language"); acceptLanguage != "" {
+				ctx := context.WithValue(c.Request().Context(), customContext.AcceptLanguageKey, acceptLanguage)
+				c.SetRequest(c.Request().WithContext(ctx))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// ClientInfo stores the client's IP address and user agent in the request context, account events record them
+func ClientInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := context.WithValue(c.Request().Context(), customContext.ClientIPKey, c.RealIP())
+			ctx = context.WithValue(ctx, customContext.UserAgentKey, c.Request().UserAgent())
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/http/response"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	redisInfra "github.com/winartodev/apollo-be/infrastructure/redis"
+	"github.com/winartodev/apollo-be/internal/domain"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+)
+
+const (
+	rateLimitRedisKey = "rate_limit:%s:%s"
+)
+
+// RateLimiter limits requests per route with the policies in the rate limit config, counted in Redis so the
+// limits hold across replicas. Policies are read on every request, so config reloads apply immediately.
+type RateLimiter struct {
+	redis  *redisInfra.Redis
+	jwt    domain.TokenService
+	config *config.Watcher
+	logger *slog.Logger
+}
+
+func NewRateLimiter(redis *redisInfra.Redis, jwt domain.TokenService, configWatcher *config.Watcher, appLogger *logger.Logger) *RateLimiter {
+	return &RateLimiter{
+		redis:  redis,
+		jwt:    jwt,
+		config: configWatcher,
+		logger: appLogger.Named("rate_limit"),
+	}
+}
+
+// Middleware sets the RateLimit-* headers and rejects requests over the limit with 429 and Retry-After.
+// Requests are let through when Redis fails, an outage shouldn't take the API down with it.
+func (rl *RateLimiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Path() == "" {
+				return next(c)
+			}
+
+			route := c.Request().Method + " " + c.Path()
+			rateLimit := rl.config.Current().RateLimit
+
+			policy, ok := rateLimit.GetPolicy(route)
+			if !ok {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			key := fmt.Sprintf(rateLimitRedisKey, route, rl.identify(c, policy.GetKey()))
+
+			var result *redisInfra.RateLimitResult
+			var err error
+			if policy.GetAlgorithm() == config.RateLimitTokenBucket {
+				result, err = rl.redis.TokenBucket(ctx, key, policy.Limit, policy.Window.Duration())
+			} else {
+				result, err = rl.redis.SlidingWindow(ctx, key, policy.Limit, policy.Window.Duration(), requestMember())
+			}
+
+			if err != nil {
+				rl.logger.WarnContext(ctx, "rate limit check failed, allowing the request", "route", route, "error", err)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(policy.Limit))
+			header.Set("RateLimit-Remaining", strconv.FormatInt(max(result.Remaining, 0), 10))
+			header.Set("RateLimit-Reset", ceilSeconds(result.Reset))
+			header.Set("RateLimit-Policy", policy.String())
+
+			if !result.Allowed {
+				header.Set("Retry-After", ceilSeconds(result.RetryAfter))
+				return response.FailedResponse(c, http.StatusTooManyRequests, domainError.ErrTooManyRequests)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// identify returns who the request is counted against, user and api_key fall back to the IP for anonymous requests.
+// The token is only read for its user ID here, the auth middleware still verifies it.
+func (rl *RateLimiter) identify(c echo.Context, key string) string {
+	switch key {
+	case config.RateLimitKeyRoute:
+		return "route"
+	case config.RateLimitKeyUser:
+		token, found := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+		if found && token != "" {
+			if claims, err := rl.jwt.ValidateAccessToken(token); err == nil {
+				return fmt.Sprintf("user:%d", claims.UserID)
+			}
+		}
+	case config.RateLimitKeyAPIKey:
+		if apiKey := c.Request().Header.Get("X-API-Key"); apiKey != "" {
+			// Keys are hashed so they never show up in Redis
+			sum := sha256.Sum256([]byte(apiKey))
+			return "api_key:" + hex.EncodeToString(sum[:])
+		}
+	}
+
+	return "ip:" + c.RealIP()
+}
+
+// requestMember is unique per request so concurrent requests in the same millisecond are all counted
+func requestMember() string {
+	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), rand.Uint64())
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,234 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/winartodev/apollo-be/infrastructure/database"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDead    = "dead"
+
+	enqueueQuery = `
+		INSERT INTO outbox (kind, payload, trace_context) VALUES ($1, $2, $3)
+	`
+
+	// claimQuery also takes back running jobs whose lease expired, their worker is gone
+	claimQuery = `
+		UPDATE outbox
+			SET status = 'running', attempts = attempts + 1, locked_until = now() + $2::DOUBLE PRECISION * INTERVAL '1 second', updated_at = now()
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE (status = 'pending' AND run_at <= now()) OR (status = 'running' AND locked_until < now())
+			ORDER BY run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, kind, payload, attempts, trace_context, created_at
+	`
+
+	completeQuery = `
+		DELETE FROM outbox WHERE id = $1
+	`
+
+	retryLaterQuery = `
+		UPDATE outbox
+			SET status = 'pending', run_at = $2, locked_until = NULL, last_error = $3, updated_at = now()
+		WHERE id = $1
+	`
+
+	deadLetterQuery = `
+		UPDATE outbox
+			SET status = 'dead', locked_until = NULL, last_error = $2, updated_at = now()
+		WHERE id = $1
+	`
+
+	countByStatusQuery = `
+		SELECT status, COUNT(*) FROM outbox GROUP BY status
+	`
+
+	getDeadJobsQuery = `
+		SELECT id, kind, attempts, COALESCE(last_error, ''), created_at, updated_at
+		FROM outbox
+		WHERE status = 'dead'
+		ORDER BY updated_at DESC
+		LIMIT $1
+	`
+
+	// pruneDeadQuery deletes dead jobs, their payloads may hold rendered emails with one-time codes
+	pruneDeadQuery = `
+		DELETE FROM outbox WHERE status = 'dead' AND updated_at < $1
+	`
+
+	retryDeadQuery = `
+		UPDATE outbox
+			SET status = 'pending', attempts = 0, run_at = now(), updated_at = now()
+		WHERE status = 'dead' AND ($1::BIGINT = 0 OR id = $1::BIGINT)
+	`
+)
+
+// Job is an outbox row claimed by the worker
+type Job struct {
+	ID       int64
+	Kind     string
+	Payload  json.RawMessage
+	Attempts int
+	// TraceContext holds the propagation headers of the request that enqueued the job
+	TraceContext propagation.MapCarrier
+	CreatedAt    time.Time
+}
+
+// DeadJob is a job that failed every attempt, it stays until it is retried or pruned
+type DeadJob struct {
+	ID        int64
+	Kind      string
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	FailedAt  time.Time
+}
+
+// Outbox stores jobs in Postgres. Enqueue joins the transaction in ctx, so the job is only
+// delivered when the change that caused it is committed.
+type Outbox struct {
+	db *database.Database
+}
+
+func NewOutbox(db *database.Database) *Outbox {
+	return &Outbox{
+		db: db,
+	}
+}
+
+// Enqueue stores the job, the worker runs it with the handler registered for kind
+func (o *Outbox) Enqueue(ctx context.Context, kind string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s job: %w", kind, err)
+	}
+
+	traceContext := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, traceContext)
+
+	traceData, err := json.Marshal(traceContext)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s job: %w", kind, err)
+	}
+
+	// lib/pq sends []byte as bytea, JSONB columns need text
+	if _, err := o.db.Conn(ctx).ExecContext(ctx, enqueueQuery, kind, string(data), string(traceData)); err != nil {
+		return fmt.Errorf("failed to enqueue %s job: %w", kind, err)
+	}
+
+	return nil
+}
+
+// Stats counts the jobs by status
+func (o *Outbox) Stats(ctx context.Context) (res map[string]int64, err error) {
+	rows, err := o.db.DB.QueryContext(ctx, countByStatusQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res = map[string]int64{StatusPending: 0, StatusRunning: 0, StatusDead: 0}
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+
+		res[status] = count
+	}
+
+	return res, rows.Err()
+}
+
+// DeadJobs returns the most recently dead-lettered jobs
+func (o *Outbox) DeadJobs(ctx context.Context, limit int) (res []DeadJob, err error) {
+	rows, err := o.db.DB.QueryContext(ctx, getDeadJobsQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var job DeadJob
+		if err := rows.Scan(&job.ID, &job.Kind, &job.Attempts, &job.LastError, &job.CreatedAt, &job.FailedAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, job)
+	}
+
+	return res, rows.Err()
+}
+
+// RetryDead moves a dead job back to pending with a fresh set of attempts, every dead job when id is 0
+func (o *Outbox) RetryDead(ctx context.Context, id int64) (count int64, err error) {
+	result, err := o.db.DB.ExecContext(ctx, retryDeadQuery, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// PruneDead deletes the jobs dead-lettered before the time
+func (o *Outbox) PruneDead(ctx context.Context, before time.Time) (deleted int64, err error) {
+	result, err := o.db.DB.ExecContext(ctx, pruneDeadQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune dead outbox jobs: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (o *Outbox) claim(ctx context.Context, limit int, lease time.Duration) (res []Job, err error) {
+	rows, err := o.db.DB.QueryContext(ctx, claimQuery, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var job Job
+		var traceData []byte
+		if err := rows.Scan(&job.ID, &job.Kind, &job.Payload, &job.Attempts, &traceData, &job.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		// A broken trace context only loses the link, the job still runs
+		_ = json.Unmarshal(traceData, &job.TraceContext)
+
+		res = append(res, job)
+	}
+
+	return res, rows.Err()
+}
+
+func (o *Outbox) complete(ctx context.Context, id int64) error {
+	_, err := o.db.DB.ExecContext(ctx, completeQuery, id)
+	return err
+}
+
+func (o *Outbox) retryLater(ctx context.Context, id int64, runAt time.Time, cause error) error {
+	_, err := o.db.DB.ExecContext(ctx, retryLaterQuery, id, runAt, cause.Error())
+	return err
+}
+
+func (o *Outbox) deadLetter(ctx context.Context, id int64, cause error) error {
+	_, err := o.db.DB.ExecContext(ctx, deadLetterQuery, id, cause.Error())
+	return err
+}
@@ -0,0 +1,252 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/infrastructure/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/winartodev/apollo-be/infrastructure/outbox")
+
+const (
+	// updateTimeout bounds the status update after a job ran, it must succeed even while draining
+	updateTimeout = 5 * time.Second
+
+	// pruneInterval is how often dead jobs older than the retention are deleted
+	pruneInterval = time.Hour
+)
+
+// Handler runs one job, an error schedules a retry. Handlers must be safe to run more than once
+// for the same job, a job is retried when its worker dies before recording the result.
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+// ErrPermanent wraps errors that retrying can't fix, the job is dead-lettered right away
+var ErrPermanent = errors.New("permanent failure")
+
+// Worker runs outbox jobs on a fixed number of goroutines. Jobs are claimed with a lease, so several
+// replicas can share the outbox and a job left running by a crashed replica is picked up again.
+type Worker struct {
+	outbox   *Outbox
+	config   *config.Outbox
+	handlers map[string]Handler
+	logger   *slog.Logger
+
+	slots   chan struct{}
+	freed   chan struct{}
+	running sync.WaitGroup
+	// stopped is closed when Start returns, no job is claimed after that
+	stopped chan struct{}
+
+	// jobCtx outlives Start so running jobs can finish while draining
+	jobCtx    context.Context
+	cancelJob context.CancelFunc
+}
+
+func NewWorker(outbox *Outbox, cfg *config.Outbox, appLogger *logger.Logger) *Worker {
+	jobCtx, cancelJob := context.WithCancel(context.Background())
+
+	return &Worker{
+		outbox:    outbox,
+		config:    cfg,
+		handlers:  make(map[string]Handler),
+		logger:    appLogger.Named("outbox"),
+		slots:     make(chan struct{}, cfg.GetWorkers()),
+		freed:     make(chan struct{}, 1),
+		stopped:   make(chan struct{}),
+		jobCtx:    jobCtx,
+		cancelJob: cancelJob,
+	}
+}
+
+// Handle registers the handler for a job kind, it must be called before Start
+func (w *Worker) Handle(kind string, handler Handler) {
+	w.handlers[kind] = handler
+}
+
+// Start claims and runs due jobs until ctx is done, jobs that are still running keep going until Drain.
+// It also deletes dead jobs older than the retention, right away and then every hour.
+func (w *Worker) Start(ctx context.Context) {
+	defer close(w.stopped)
+
+	ticker := time.NewTicker(w.config.GetPollInterval())
+	defer ticker.Stop()
+
+	pruneTicker := time.NewTicker(pruneInterval)
+	defer pruneTicker.Stop()
+
+	w.pruneDead(ctx)
+
+	for {
+		w.poll(ctx)
+
+		// A finished job frees a worker, poll right away in case there is a backlog
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.freed:
+		case <-pruneTicker.C:
+			w.pruneDead(ctx)
+		}
+	}
+}
+
+// Drain waits for Start to return and then for the running jobs, it must be called after Start.
+// When ctx expires first the jobs are cancelled and retried later.
+func (w *Worker) Drain(ctx context.Context) error {
+	// A poll in progress may still add jobs, waiting on running before it returns could miss them
+	select {
+	case <-w.stopped:
+	case <-ctx.Done():
+		w.cancelJob()
+		return fmt.Errorf("outbox worker didn't stop while draining: %w", ctx.Err())
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		w.cancelJob()
+		<-done
+		return fmt.Errorf("outbox jobs were cancelled while draining: %w", ctx.Err())
+	}
+}
+
+func (w *Worker) poll(ctx context.Context) {
+	free := cap(w.slots) - len(w.slots)
+	if free == 0 {
+		return
+	}
+
+	jobs, err := w.outbox.claim(ctx, free, w.config.GetLease())
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.ErrorContext(ctx, "failed to claim outbox jobs", "error", err)
+		}
+
+		return
+	}
+
+	for _, job := range jobs {
+		w.slots <- struct{}{}
+		w.running.Add(1)
+
+		go func() {
+			defer func() {
+				<-w.slots
+				w.running.Done()
+
+				select {
+				case w.freed <- struct{}{}:
+				default:
+				}
+			}()
+
+			w.run(job)
+		}()
+	}
+}
+
+// pruneDead deletes the jobs dead-lettered before the retention. Replicas may prune at the same time,
+// they delete the same rows.
+func (w *Worker) pruneDead(ctx context.Context) {
+	deleted, err := w.outbox.PruneDead(ctx, time.Now().Add(-w.config.GetDeadRetention()))
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.ErrorContext(ctx, "failed to prune dead outbox jobs", "error", err)
+		}
+
+		return
+	}
+
+	if deleted > 0 {
+		w.logger.InfoContext(ctx, "dead outbox jobs pruned", "deleted", deleted)
+	}
+}
+
+// run records the job in its own trace, linked to the request that enqueued it
+func (w *Worker) run(job Job) {
+	enqueuedBy := otel.GetTextMapPropagator().Extract(context.Background(), job.TraceContext)
+
+	ctx, cancel := context.WithTimeout(w.jobCtx, w.config.GetLease())
+	ctx, span := tracing.StartLinked(ctx, enqueuedBy, tracer, "outbox."+job.Kind,
+		trace.WithAttributes(attribute.Int64("outbox.job_id", job.ID), attribute.Int("outbox.attempt", job.Attempts)),
+	)
+
+	err := w.runHandler(ctx, job)
+	tracing.End(span, err)
+	cancel()
+
+	ctx, cancel = context.WithTimeout(context.Background(), updateTimeout)
+	defer cancel()
+
+	log := w.logger.With("job_id", job.ID, "kind", job.Kind, "attempt", job.Attempts)
+
+	if err == nil {
+		if err := w.outbox.complete(ctx, job.ID); err != nil {
+			log.ErrorContext(ctx, "failed to complete outbox job, it will run again", "error", err)
+		}
+
+		return
+	}
+
+	if errors.Is(err, ErrPermanent) || job.Attempts >= w.config.GetMaxAttempts() {
+		log.ErrorContext(ctx, "outbox job dead-lettered", "error", err)
+		if err := w.outbox.deadLetter(ctx, job.ID, err); err != nil {
+			log.ErrorContext(ctx, "failed to dead-letter outbox job", "error", err)
+		}
+
+		return
+	}
+
+	retryAt := time.Now().Add(w.backoff(job.Attempts))
+	log.WarnContext(ctx, "outbox job failed, retrying", "retry_at", retryAt, "error", err)
+	if err := w.outbox.retryLater(ctx, job.ID, retryAt, err); err != nil {
+		log.ErrorContext(ctx, "failed to reschedule outbox job", "error", err)
+	}
+}
+
+func (w *Worker) runHandler(ctx context.Context, job Job) (err error) {
+	handler, ok := w.handlers[job.Kind]
+	if !ok {
+		return fmt.Errorf("%w: no handler for %s jobs", ErrPermanent, job.Kind)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panicked: %v", p)
+		}
+	}()
+
+	return handler(ctx, job.Payload)
+}
+
+// backoff doubles the delay after every attempt, jittered between half and the full delay so retries spread out
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.config.GetMinBackoff()
+	for i := 1; i < attempts && delay < w.config.GetMaxBackoff(); i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, w.config.GetMaxBackoff())
+
+	return delay/2 + rand.N(delay/2+1)
+}
@@ -0,0 +1,91 @@
+package phone
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ttacon/libphonenumber"
+	"github.com/winartodev/apollo-be/config"
+)
+
+var (
+	ErrInvalidPhoneNumber     = errors.New("invalid phone number")
+	ErrUnsupportedPhoneRegion = errors.New("unsupported phone region")
+	ErrPhoneTypeNotAllowed    = errors.New("phone number type not allowed")
+
+	phoneTypes = map[string]libphonenumber.PhoneNumberType{
+		config.PhoneTypeMobile:            libphonenumber.MOBILE,
+		config.PhoneTypeFixedLine:         libphonenumber.FIXED_LINE,
+		config.PhoneTypeFixedLineOrMobile: libphonenumber.FIXED_LINE_OR_MOBILE,
+		config.PhoneTypeVoip:              libphonenumber.VOIP,
+	}
+)
+
+type PhoneNumberService interface {
+	// Normalize parses the number, using region when it has no country calling code, and returns it in E.164
+	Normalize(number string, region string) (res string, err error)
+	IsE164(number string) bool
+	DefaultRegion() string
+}
+
+type phoneNumberService struct {
+	defaultRegion string
+	allowedTypes  map[libphonenumber.PhoneNumberType]struct{}
+}
+
+func NewPhoneNumberService(phoneConfig *config.Phone) PhoneNumberService {
+	allowedTypes := make(map[libphonenumber.PhoneNumberType]struct{})
+	for _, name := range phoneConfig.GetAllowedTypes() {
+		if numberType, ok := phoneTypes[strings.ToLower(name)]; ok {
+			allowedTypes[numberType] = struct{}{}
+		}
+	}
+
+	return &phoneNumberService{
+		defaultRegion: phoneConfig.GetDefaultRegion(),
+		allowedTypes:  allowedTypes,
+	}
+}
+
+func (ps *phoneNumberService) Normalize(number string, region string) (res string, err error) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if region == "" {
+		region = ps.defaultRegion
+	}
+
+	if libphonenumber.GetCountryCodeForRegion(region) == 0 {
+		return "", ErrUnsupportedPhoneRegion
+	}
+
+	parsed, err := libphonenumber.Parse(number, region)
+	if err != nil {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	// IsValidNumber checks the length and prefixes against the metadata of the number's own region
+	if !libphonenumber.IsValidNumber(parsed) {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	if len(ps.allowedTypes) > 0 {
+		if _, ok := ps.allowedTypes[libphonenumber.GetNumberType(parsed)]; !ok {
+			return "", ErrPhoneTypeNotAllowed
+		}
+	}
+
+	return libphonenumber.Format(parsed, libphonenumber.E164), nil
+}
+
+// IsE164 reports whether the number is already normalized and valid
+func (ps *phoneNumberService) IsE164(number string) bool {
+	if !strings.HasPrefix(number, "+") {
+		return false
+	}
+
+	normalized, err := ps.Normalize(number, "")
+	return err == nil && normalized == number
+}
+
+func (ps *phoneNumberService) DefaultRegion() string {
+	return ps.defaultRegion
+}
@@ -0,0 +1,60 @@
+package phone
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-playground/validator"
+)
+
+const (
+	TagE164  = "e164"
+	TagPhone = "phone"
+)
+
+// RegionResolver returns the alpha-2 code of an ISO 3166-1 alpha-2, alpha-3 or numeric country code
+type RegionResolver func(ctx context.Context, code string) (region string, err error)
+
+// RegisterValidations adds the e164 and phone tags to the validator.
+// e164 accepts only numbers already in E.164 form, replacing the format only check of the built-in tag.
+// phone accepts any format, its optional param is either a country code (phone=ID) or the name of a sibling
+// field holding one (phone=CountryCode), in any ISO 3166-1 format. The default region is used otherwise.
+func RegisterValidations(v *validator.Validate, phoneService PhoneNumberService, resolveRegion RegionResolver) error {
+	if err := v.RegisterValidation(TagE164, func(fl validator.FieldLevel) bool {
+		return phoneService.IsE164(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+
+	return v.RegisterValidation(TagPhone, func(fl validator.FieldLevel) bool {
+		_, err := phoneService.Normalize(fl.Field().String(), regionFromParam(fl, resolveRegion))
+		return err == nil
+	})
+}
+
+func regionFromParam(fl validator.FieldLevel, resolveRegion RegionResolver) string {
+	code := fl.Param()
+	if code == "" {
+		return ""
+	}
+
+	if field, kind, ok := fl.GetStructFieldOK(); ok {
+		if kind != reflect.String {
+			return ""
+		}
+
+		code = field.String()
+	}
+
+	if code == "" {
+		return ""
+	}
+
+	// An unknown code falls back to the default region, the country code itself is rejected by the use case
+	region, err := resolveRegion(context.Background(), code)
+	if err != nil {
+		return ""
+	}
+
+	return region
+}
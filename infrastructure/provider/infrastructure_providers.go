@@ -5,9 +5,13 @@ import (
 	"github.com/winartodev/apollo-be/infrastructure/auth"
 	"github.com/winartodev/apollo-be/infrastructure/database"
 	"github.com/winartodev/apollo-be/infrastructure/middleware"
+	"github.com/winartodev/apollo-be/infrastructure/outbox"
+	"github.com/winartodev/apollo-be/infrastructure/phone"
 	"github.com/winartodev/apollo-be/infrastructure/redis"
 	"github.com/winartodev/apollo-be/infrastructure/smtp"
+	"github.com/winartodev/apollo-be/infrastructure/storage"
 	"github.com/winartodev/apollo-be/internal/application/service"
+	"github.com/winartodev/apollo-be/internal/domain"
 )
 
 // InfraProviderSet contains infrastructure implementations
@@ -16,9 +20,16 @@ var InfraProviderSet = wire.NewSet(
 	auth.NewJWT,
 	auth.NewJwtTokenService,
 	auth.NewBcryptPasswordService,
+	auth.NewUserStatusService,
+	auth.NewAPIKeyService,
 	database.NewDatabase,
+	wire.Bind(new(domain.Transactor), new(*database.Database)),
+	outbox.NewOutbox,
 	redis.NewRedis,
-	smtp.NewSMTPService,
+	smtp.NewTemplateService,
+	smtp.NewEmailQueue,
+	phone.NewPhoneNumberService,
+	storage.NewStorage,
 )
 
 // MiddlewareProviderSet contains middleware components
@@ -0,0 +1,155 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Both scripts use the Redis clock so every replica shares one view of time.
+// They return {allowed, remaining, reset_ms, retry_after_ms}.
+
+// slidingWindowScript keeps one sorted set member per accepted request within the window
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local member = ARGV[3]
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, member)
+	count = count + 1
+	allowed = 1
+end
+
+redis.call('PEXPIRE', key, window)
+
+local reset = window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+local retry = 0
+if allowed == 0 then
+	retry = reset
+end
+
+return {allowed, limit - count, reset, retry}
+`)
+
+// tokenBucketScript refills one token every interval up to the capacity
+var tokenBucketScript = redis.NewScript(`
+local key = KEYS[1]
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', key, 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+local refill = math.floor((now - ts) / interval)
+if refill > 0 then
+	tokens = math.min(capacity, tokens + refill)
+	ts = ts + refill * interval
+end
+
+if tokens >= capacity then
+	ts = now
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', key, 'tokens', tokens, 'ts', ts)
+redis.call('PEXPIRE', key, capacity * interval)
+
+local elapsed = now - ts
+local reset = (capacity - tokens) * interval - elapsed
+if reset < 0 then
+	reset = 0
+end
+
+local retry = 0
+if allowed == 0 then
+	retry = interval - elapsed
+end
+
+return {allowed, tokens, reset, retry}
+`)
+
+// RateLimitResult is the outcome of one rate limited request
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int64
+	Reset      time.Duration // until the full quota is available again
+	RetryAfter time.Duration // until the next request is allowed, zero when allowed
+}
+
+// SlidingWindow allows limit requests in any window long period. The member must be unique per request.
+func (r *Redis) SlidingWindow(ctx context.Context, key string, limit int, window time.Duration, member string) (*RateLimitResult, error) {
+	return r.runRateLimitScript(ctx, slidingWindowScript, key, limit, window.Milliseconds(), member)
+}
+
+// TokenBucket allows bursts of capacity requests and refills the bucket completely over the window
+func (r *Redis) TokenBucket(ctx context.Context, key string, capacity int, window time.Duration) (*RateLimitResult, error) {
+	interval := window.Milliseconds() / int64(capacity)
+	if interval < 1 {
+		interval = 1
+	}
+
+	return r.runRateLimitScript(ctx, tokenBucketScript, key, capacity, interval)
+}
+
+func (r *Redis) runRateLimitScript(ctx context.Context, script *redis.Script, key string, args ...interface{}) (*RateLimitResult, error) {
+	values, err := script.Run(ctx, r.client, []string{key}, args...).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("redisutil: failed to run rate limit script: %w", err)
+	}
+
+	if len(values) != 4 {
+		return nil, fmt.Errorf("redisutil: unexpected rate limit script result %v", values)
+	}
+
+	return &RateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  values[1],
+		Reset:      time.Duration(values[2]) * time.Millisecond,
+		RetryAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
+
+// IncrWithExpire increments the counter and sets its expiration in one transaction,
+// so a failure can't leave a counter that never expires
+func (r *Redis) IncrWithExpire(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
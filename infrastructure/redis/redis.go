@@ -37,6 +37,26 @@ func (r *Redis) SetEx(ctx context.Context, key string, value interface{}, expira
 	return r.client.SetEx(ctx, key, jsonData, expiration).Err()
 }
 
+// Set sets key-value without expiration, automatically marshalling the value
+func (r *Redis) Set(ctx context.Context, key string, value interface{}) error {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("redisutil: failed to marshal value: %w", err)
+	}
+
+	return r.client.Set(ctx, key, jsonData, 0).Err()
+}
+
+// SetNX sets key-value with expiration only when the key doesn't exist, and reports whether it was set
+func (r *Redis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("redisutil: failed to marshal value: %w", err)
+	}
+
+	return r.client.SetNX(ctx, key, jsonData, expiration).Result()
+}
+
 // Get gets a value by key and unmarshal it into the destination
 func (r *Redis) Get(ctx context.Context, key string, dest interface{}) error {
 	data, err := r.client.Get(ctx, key).Bytes()
@@ -51,6 +71,20 @@ func (r *Redis) Get(ctx context.Context, key string, dest interface{}) error {
 	return nil
 }
 
+// GetDel gets a value by key and deletes it atomically, so only one caller gets it
+func (r *Redis) GetDel(ctx context.Context, key string, dest interface{}) error {
+	data, err := r.client.GetDel(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("redisutil: failed to unmarshal data for key %s: %w", key, err)
+	}
+
+	return nil
+}
+
 // Delete removes one or more keys
 func (r *Redis) Delete(ctx context.Context, keys ...string) error {
 	return r.client.Del(ctx, keys...).Err()
@@ -76,3 +110,8 @@ func (r *Redis) DecrBy(ctx context.Context, key string, decrement int64) (int64,
 func (r *Redis) Expire(ctx context.Context, key string, expiration time.Duration) error {
 	return r.client.Expire(ctx, key, expiration).Err()
 }
+
+// TTL returns the remaining time to live of the key, negative when it doesn't exist or never expires
+func (r *Redis) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return r.client.TTL(ctx, key).Result()
+}
@@ -16,7 +16,6 @@ type APIRouteItf interface {
 
 func RegisterHandler(e *echo.Echo, handlers ...APIRouteItf) error {
 	api := e.Group("/api")
-	api.GET("/health-check", HealthCheck)
 
 	for _, apiRoute := range handlers {
 		if err := apiRoute.RegisterRoutes(api); err != nil {
@@ -0,0 +1,64 @@
+package smtp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/winartodev/apollo-be/infrastructure/outbox"
+)
+
+// EmailJobKind is the outbox job kind of queued emails
+const EmailJobKind = "email"
+
+// EmailMessage is the payload of an email job
+type EmailMessage struct {
+	To       string `json:"to"`
+	Subject  string `json:"subject"`
+	HTMLBody string `json:"html_body"`
+	TextBody string `json:"text_body,omitempty"`
+}
+
+// EmailQueue sends emails through the outbox, so they survive SMTP outages and restarts.
+// Called inside a transaction, the email is only sent once it commits.
+type EmailQueue interface {
+	Enqueue(ctx context.Context, recipient string, email *Email) error
+}
+
+type emailQueue struct {
+	outbox *outbox.Outbox
+}
+
+// NewEmailQueue creates a new email queue instance
+func NewEmailQueue(outbox *outbox.Outbox) EmailQueue {
+	return &emailQueue{
+		outbox: outbox,
+	}
+}
+
+// Enqueue stores a rendered email for the worker to send
+func (q *emailQueue) Enqueue(ctx context.Context, recipient string, email *Email) error {
+	return q.outbox.Enqueue(ctx, EmailJobKind, EmailMessage{
+		To:       recipient,
+		Subject:  email.Subject,
+		HTMLBody: email.HTMLBody,
+		TextBody: email.TextBody,
+	})
+}
+
+// NewEmailJobHandler sends queued emails with the mailer
+func NewEmailJobHandler(mailer Mailer) outbox.Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		var message EmailMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			return fmt.Errorf("%w: invalid email payload: %v", outbox.ErrPermanent, err)
+		}
+
+		return mailer.Send(ctx, &Message{
+			To:       []string{message.To},
+			Subject:  message.Subject,
+			TextBody: message.TextBody,
+			HTMLBody: message.HTMLBody,
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package smtp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+)
+
+// MailboxMessage is a message kept by the mailbox
+type MailboxMessage struct {
+	ID          int64             `json:"id"`
+	ReceivedAt  time.Time         `json:"received_at"`
+	From        string            `json:"from"`
+	To          []string          `json:"to"`
+	ReplyTo     string            `json:"reply_to,omitempty"`
+	Subject     string            `json:"subject"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	TextBody    string            `json:"text_body"`
+	HTMLBody    string            `json:"html_body"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+}
+
+// Mailbox is the development transport, it keeps the latest messages in memory instead of sending them
+// and shows them on /dev/mail
+type Mailbox struct {
+	sender string
+	size   int
+
+	mu       sync.RWMutex
+	lastID   int64
+	messages []MailboxMessage // oldest first
+}
+
+// NewMailbox creates a new mailbox keeping smtp.mailboxSize messages
+func NewMailbox(smtpConfig *config.SMTPConfig) *Mailbox {
+	return &Mailbox{
+		sender: smtpConfig.Sender,
+		size:   smtpConfig.GetMailboxSize(),
+	}
+}
+
+func (m *Mailbox) Send(ctx context.Context, message *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastID++
+	m.messages = append(m.messages, MailboxMessage{
+		ID:          m.lastID,
+		ReceivedAt:  time.Now().UTC(),
+		From:        m.sender,
+		To:          message.To,
+		ReplyTo:     message.ReplyTo,
+		Subject:     message.Subject,
+		Headers:     message.Headers,
+		TextBody:    message.TextBody,
+		HTMLBody:    message.HTMLBody,
+		Attachments: message.Attachments,
+	})
+
+	if len(m.messages) > m.size {
+		m.messages = m.messages[len(m.messages)-m.size:]
+	}
+
+	return nil
+}
+
+func (m *Mailbox) Close() error {
+	return nil
+}
+
+// Messages returns the kept messages, newest first
+func (m *Mailbox) Messages() []MailboxMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	messages := make([]MailboxMessage, 0, len(m.messages))
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		messages = append(messages, m.messages[i])
+	}
+
+	return messages
+}
+
+// Message returns the message with the ID, false when it was dropped or never existed
+func (m *Mailbox) Message(id int64) (MailboxMessage, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, message := range m.messages {
+		if message.ID == id {
+			return message, true
+		}
+	}
+
+	return MailboxMessage{}, false
+}
+
+// Clear drops every message
+func (m *Mailbox) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
@@ -0,0 +1,161 @@
+package smtp
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/winartodev/apollo-be/infrastructure/http/response"
+)
+
+// mailboxPage lists the messages and shows the selected one, its HTML body is sandboxed in an iframe
+var mailboxPage = template.Must(template.New("mailbox").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>Dev mailbox</title>
+    <style>
+        body { font-family: Arial, sans-serif; margin: 0; display: flex; height: 100vh; color: #333; }
+        nav { width: 340px; overflow-y: auto; border-right: 1px solid #ddd; }
+        nav a { display: block; padding: 10px 14px; border-bottom: 1px solid #eee; color: inherit; text-decoration: none; }
+        nav a.selected { background: #eef3ff; }
+        nav small, dl { color: #666; font-size: 12px; }
+        main { flex: 1; display: flex; flex-direction: column; padding: 14px; }
+        dl { display: grid; grid-template-columns: max-content 1fr; gap: 4px 12px; margin: 0 0 12px; }
+        dd { margin: 0; }
+        iframe { flex: 1; border: 1px solid #ddd; }
+        pre { white-space: pre-wrap; max-height: 30vh; overflow-y: auto; background: #f7f7f7; padding: 10px; }
+    </style>
+</head>
+<body>
+<nav>
+    <form method="post" action="/dev/mail/clear"><button type="submit">Clear</button></form>
+    {{range .Messages}}
+    <a href="/dev/mail?id={{.ID}}" {{if and $.Selected (eq .ID $.Selected.ID)}}class="selected"{{end}}>
+        {{.Subject}}<br><small>{{range .To}}{{.}} {{end}}· {{.ReceivedAt.Format "15:04:05"}}</small>
+    </a>
+    {{else}}
+    <p>&nbsp;No emails yet.</p>
+    {{end}}
+</nav>
+<main>
+    {{with .Selected}}
+    <h3>{{.Subject}}</h3>
+    <dl>
+        <dt>From</dt><dd>{{.From}}</dd>
+        <dt>To</dt><dd>{{range .To}}{{.}} {{end}}</dd>
+        {{if .ReplyTo}}<dt>Reply-To</dt><dd>{{.ReplyTo}}</dd>{{end}}
+        {{range $name, $value := .Headers}}<dt>{{$name}}</dt><dd>{{$value}}</dd>{{end}}
+        <dt>Received</dt><dd>{{.ReceivedAt.Format "2006-01-02 15:04:05 MST"}}</dd>
+        {{range $index, $attachment := .Attachments}}<dt>Attachment</dt><dd><a href="/dev/mail/messages/{{$.Selected.ID}}/attachments/{{$index}}">{{.Filename}}</a></dd>{{end}}
+    </dl>
+    {{if .HTMLBody}}<iframe sandbox src="/dev/mail/messages/{{.ID}}/html"></iframe>{{end}}
+    {{if .TextBody}}<pre>{{.TextBody}}</pre>{{end}}
+    {{end}}
+</main>
+</body>
+</html>
+`))
+
+// RegisterRoutes serves the mailbox viewer and its JSON API, only registered with the mailbox transport in development
+func (m *Mailbox) RegisterRoutes(e *echo.Echo) {
+	e.GET("/dev/mail", m.ViewMailbox)
+	e.POST("/dev/mail/clear", m.ClearMailbox)
+	e.GET("/dev/mail/messages", m.GetMessages)
+	e.DELETE("/dev/mail/messages", m.DeleteMessages)
+	e.GET("/dev/mail/messages/:id", m.GetMessage)
+	e.GET("/dev/mail/messages/:id/html", m.GetMessageHTML)
+	e.GET("/dev/mail/messages/:id/attachments/:index", m.GetAttachment)
+}
+
+// ViewMailbox renders the viewer, the newest message is shown unless ?id= selects another one
+func (m *Mailbox) ViewMailbox(c echo.Context) error {
+	messages := m.Messages()
+
+	var selected *MailboxMessage
+	if id, err := strconv.ParseInt(c.QueryParam("id"), 10, 64); err == nil {
+		if message, ok := m.Message(id); ok {
+			selected = &message
+		}
+	} else if len(messages) > 0 {
+		selected = &messages[0]
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	c.Response().WriteHeader(http.StatusOK)
+
+	return mailboxPage.Execute(c.Response(), map[string]interface{}{
+		"Messages": messages,
+		"Selected": selected,
+	})
+}
+
+func (m *Mailbox) ClearMailbox(c echo.Context) error {
+	m.Clear()
+	return c.Redirect(http.StatusSeeOther, "/dev/mail")
+}
+
+func (m *Mailbox) GetMessages(c echo.Context) error {
+	return response.SuccessResponse(c, http.StatusOK, "OK", m.Messages(), nil)
+}
+
+func (m *Mailbox) DeleteMessages(c echo.Context) error {
+	m.Clear()
+	return response.SuccessResponse(c, http.StatusOK, "OK", nil, nil)
+}
+
+func (m *Mailbox) GetMessage(c echo.Context) error {
+	message, err := m.messageFromParam(c)
+	if err != nil {
+		return err
+	}
+
+	return response.SuccessResponse(c, http.StatusOK, "OK", message, nil)
+}
+
+func (m *Mailbox) GetMessageHTML(c echo.Context) error {
+	message, err := m.messageFromParam(c)
+	if err != nil {
+		return err
+	}
+
+	return c.HTML(http.StatusOK, message.HTMLBody)
+}
+
+func (m *Mailbox) GetAttachment(c echo.Context) error {
+	message, err := m.messageFromParam(c)
+	if err != nil {
+		return err
+	}
+
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil || index < 0 || index >= len(message.Attachments) {
+		return echo.ErrNotFound
+	}
+
+	attachment := message.Attachments[index]
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", attachment.Filename))
+
+	return c.Blob(http.StatusOK, contentType, attachment.Content)
+}
+
+func (m *Mailbox) messageFromParam(c echo.Context) (*MailboxMessage, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, echo.ErrNotFound
+	}
+
+	message, ok := m.Message(id)
+	if !ok {
+		return nil, echo.ErrNotFound
+	}
+
+	return &message, nil
+}
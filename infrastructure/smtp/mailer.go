@@ -0,0 +1,87 @@
+package smtp
+
+import (
+	"context"
+	"io"
+
+	"github.com/winartodev/apollo-be/config"
+	"gopkg.in/gomail.v2"
+)
+
+// Message is an email ready to be sent, the sender is set by the transport
+type Message struct {
+	To          []string
+	ReplyTo     string
+	Subject     string
+	TextBody    string
+	HTMLBody    string
+	Headers     map[string]string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"-"`
+}
+
+// Mailer delivers messages, the transport is chosen by smtp.transport
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+	// Close releases the transport's connections, messages can't be sent afterwards
+	Close() error
+}
+
+// NewMailer returns the SMTP transport, or the dev mailbox when smtp.transport is mailbox
+func NewMailer(smtpConfig *config.SMTPConfig) Mailer {
+	if smtpConfig.GetTransport() == config.SMTPTransportMailbox {
+		return NewMailbox(smtpConfig)
+	}
+
+	return NewSMTPMailer(smtpConfig)
+}
+
+// buildMessage converts the message, a message with both bodies is sent as multipart/alternative
+// so clients that can't display HTML show the plain text one
+func buildMessage(sender string, message *Message) *gomail.Message {
+	m := gomail.NewMessage()
+	for name, value := range message.Headers {
+		m.SetHeader(name, value)
+	}
+
+	m.SetHeader("From", sender)
+	m.SetHeader("To", message.To...)
+	m.SetHeader("Subject", message.Subject)
+	if message.ReplyTo != "" {
+		m.SetHeader("Reply-To", message.ReplyTo)
+	}
+
+	switch {
+	case message.TextBody != "" && message.HTMLBody != "":
+		m.SetBody("text/plain", message.TextBody)
+		m.AddAlternative("text/html", message.HTMLBody)
+	case message.HTMLBody != "":
+		m.SetBody("text/html", message.HTMLBody)
+	default:
+		m.SetBody("text/plain", message.TextBody)
+	}
+
+	for _, attachment := range message.Attachments {
+		content := attachment.Content
+		settings := []gomail.FileSetting{
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}),
+		}
+
+		if attachment.ContentType != "" {
+			settings = append(settings, gomail.SetHeader(map[string][]string{"Content-Type": {attachment.ContentType}}))
+		}
+
+		m.Attach(attachment.Filename, settings...)
+	}
+
+	return m
+}
@@ -0,0 +1,171 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+	"gopkg.in/gomail.v2"
+)
+
+var errMailerClosed = errors.New("mailer is closed")
+
+// smtpMailer sends messages over a pool of persistent connections to the SMTP server
+type smtpMailer struct {
+	config *config.SMTPConfig
+
+	// slots limits the connections open at the same time to the pool size
+	slots chan struct{}
+
+	mu     sync.Mutex
+	idle   []*pooledConn
+	closed bool
+}
+
+type pooledConn struct {
+	sender   gomail.SendCloser
+	lastUsed time.Time
+}
+
+// NewSMTPMailer creates a new SMTP transport, connections are opened on the first message
+func NewSMTPMailer(smtpConfig *config.SMTPConfig) Mailer {
+	return &smtpMailer{
+		config: smtpConfig,
+		slots:  make(chan struct{}, smtpConfig.GetPoolSize()),
+	}
+}
+
+// Send waits for a free connection and sends the message within smtp.timeout.
+// gomail can't be interrupted, a message still being written when the context ends may be sent anyway.
+func (m *smtpMailer) Send(ctx context.Context, message *Message) error {
+	ctx, cancel := context.WithTimeout(ctx, m.config.GetTimeout())
+	defer cancel()
+
+	select {
+	case m.slots <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to send email: %w", ctx.Err())
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() { <-m.slots }()
+		done <- m.send(ctx, buildMessage(m.config.Sender, message))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to send email: %w", ctx.Err())
+	}
+}
+
+func (m *smtpMailer) send(ctx context.Context, message *gomail.Message) error {
+	conn, reused, err := m.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = gomail.Send(conn.sender, message)
+	if err != nil && reused {
+		// The server may have dropped the idle connection, retry once on a new one
+		_ = conn.sender.Close()
+
+		if conn, err = m.dial(ctx); err != nil {
+			return err
+		}
+
+		err = gomail.Send(conn.sender, message)
+	}
+
+	if err != nil {
+		_ = conn.sender.Close()
+		return err
+	}
+
+	m.put(conn)
+
+	return nil
+}
+
+// get returns an idle connection, or a new one when there is none or it was idle for too long
+func (m *smtpMailer) get(ctx context.Context) (conn *pooledConn, reused bool, err error) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil, false, errMailerClosed
+	}
+
+	for len(m.idle) > 0 && conn == nil {
+		conn = m.idle[len(m.idle)-1]
+		m.idle = m.idle[:len(m.idle)-1]
+
+		if time.Since(conn.lastUsed) > m.config.GetIdleTimeout() {
+			_ = conn.sender.Close()
+			conn = nil
+		}
+	}
+	m.mu.Unlock()
+
+	if conn != nil {
+		return conn, true, nil
+	}
+
+	conn, err = m.dial(ctx)
+
+	return conn, false, err
+}
+
+func (m *smtpMailer) put(conn *pooledConn) {
+	conn.lastUsed = time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		_ = conn.sender.Close()
+		return
+	}
+
+	m.idle = append(m.idle, conn)
+}
+
+// dial reads the password for every connection so a rotated secret is used without a restart
+func (m *smtpMailer) dial(ctx context.Context) (*pooledConn, error) {
+	password, err := m.config.GetPassword(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := gomail.NewDialer(m.config.Host, m.config.Port, m.config.Sender, password).Dial()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pooledConn{sender: sender, lastUsed: time.Now()}, nil
+}
+
+// Close closes the idle connections, connections in use are closed once their message is sent
+func (m *smtpMailer) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+
+	var errs []error
+	for _, conn := range m.idle {
+		errs = append(errs, conn.sender.Close())
+	}
+
+	m.idle = nil
+
+	return errors.Join(errs...)
+}
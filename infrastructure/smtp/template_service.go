@@ -0,0 +1,334 @@
+package smtp
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	textTemplate "text/template"
+
+	"github.com/winartodev/apollo-be/config"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OtpEmail             = "otp"
+	InvitationEmail      = "invitation"
+	PasswordChangedEmail = "password_changed"
+	NewDeviceSignInEmail = "new_device_sign_in"
+	AccountLockedEmail   = "account_locked"
+
+	// DefaultLocale is used when no preferred locale is supported, its catalog has every message
+	DefaultLocale = "en"
+
+	layoutName = "layout"
+	localesDir = "locales"
+)
+
+// ErrUnknownTemplate is returned for a name that isn't registered
+var ErrUnknownTemplate = errors.New("unknown email template")
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// emailSamples registers the templates with the data the preview renders them with,
+// which is also the data the template expects
+var emailSamples = map[string]map[string]interface{}{
+	OtpEmail: {
+		"code":             "123456",
+		"expiresInMinutes": 3,
+	},
+	InvitationEmail: {
+		"inviter":   "jane",
+		"code":      "ABCD1234",
+		"link":      "https://example.com/sign-up?invite=ABCD1234",
+		"expiresAt": "Mon, 02 Jan 2006 15:04:05 UTC",
+	},
+	PasswordChangedEmail: {
+		"username":   "jane",
+		"time":       "Mon, 02 Jan 2006 15:04:05 UTC",
+		"reportLink": "https://example.com/not-me?token=abc",
+	},
+	NewDeviceSignInEmail: {
+		"username":   "jane",
+		"time":       "Mon, 02 Jan 2006 15:04:05 UTC",
+		"device":     "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+		"ip":         "203.0.113.7",
+		"reportLink": "https://example.com/not-me?token=abc",
+	},
+	AccountLockedEmail: {
+		"username":   "jane",
+		"time":       "Mon, 02 Jan 2006 15:04:05 UTC",
+		"until":      "Mon, 02 Jan 2006 15:19:05 UTC",
+		"reportLink": "https://example.com/not-me?token=abc",
+	},
+}
+
+// Email is a rendered email with HTML and plain text alternatives
+type Email struct {
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// TemplateService renders the registered email templates in the shared layout.
+// Every template has <name>.html and <name>.txt, a <name>.<locale>.html variant replaces the default one,
+// and messages come from the locales/<locale>.yaml catalogs.
+type TemplateService interface {
+	Render(name string, locale string, data map[string]interface{}) (*Email, error)
+	// ResolveLocale picks the best supported locale from the candidates in order of preference,
+	// each one a language tag or an Accept-Language header
+	ResolveLocale(candidates ...string) string
+	Templates() []string
+	Sample(name string) map[string]interface{}
+}
+
+// templateService implements TemplateService, a file with the same name in the override directory wins
+type templateService struct {
+	overrideDir string
+	embedded    fs.FS
+	locales     []string
+	matcher     language.Matcher
+}
+
+// NewTemplateService creates a new template service instance
+func NewTemplateService(smtpConfig *config.SMTPConfig) TemplateService {
+	embedded, _ := fs.Sub(embeddedTemplates, "templates")
+
+	s := &templateService{
+		overrideDir: smtpConfig.TemplateDir,
+		embedded:    embedded,
+	}
+
+	s.locales = s.findLocales()
+	tags := make([]language.Tag, 0, len(s.locales))
+	for _, locale := range s.locales {
+		tags = append(tags, language.Make(locale))
+	}
+
+	s.matcher = language.NewMatcher(tags)
+
+	return s
+}
+
+// Render executes the named template, files are read on every call so overrides apply without a restart
+func (s *templateService) Render(name string, locale string, data map[string]interface{}) (*Email, error) {
+	if _, ok := emailSamples[name]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+	}
+
+	translate := s.translator(locale)
+
+	// The caller's map is not modified
+	values := make(map[string]interface{}, len(data)+1)
+	for key, value := range data {
+		values[key] = value
+	}
+
+	values["lang"] = locale
+
+	htmlBody, err := s.renderHTML(name, locale, translate, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render email template %s: %w", name, err)
+	}
+
+	textBody, err := s.renderText(name, locale, translate, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render email template %s: %w", name, err)
+	}
+
+	return &Email{
+		Subject:  translate(name + ".subject"),
+		HTMLBody: htmlBody,
+		TextBody: textBody,
+	}, nil
+}
+
+func (s *templateService) ResolveLocale(candidates ...string) string {
+	var preferred []language.Tag
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+
+		tags, _, err := language.ParseAcceptLanguage(candidate)
+		if err == nil {
+			preferred = append(preferred, tags...)
+		}
+	}
+
+	if len(preferred) == 0 {
+		return DefaultLocale
+	}
+
+	// The default locale comes first, so it is the one picked when nothing matches
+	_, index, _ := s.matcher.Match(preferred...)
+
+	return s.locales[index]
+}
+
+func (s *templateService) Templates() []string {
+	names := make([]string, 0, len(emailSamples))
+	for name := range emailSamples {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Sample returns a copy of the template's sample data, nil for an unknown template
+func (s *templateService) Sample(name string) map[string]interface{} {
+	sample, ok := emailSamples[name]
+	if !ok {
+		return nil
+	}
+
+	data := make(map[string]interface{}, len(sample))
+	for key, value := range sample {
+		data[key] = value
+	}
+
+	return data
+}
+
+func (s *templateService) renderHTML(name string, locale string, translate func(key string, args ...interface{}) string, data map[string]interface{}) (string, error) {
+	tmpl := htmlTemplate.New(name).Funcs(htmlTemplate.FuncMap{"t": translate})
+	for _, file := range []string{layoutName + ".html", s.variant(name, locale, ".html")} {
+		content, err := s.read(file)
+		if err != nil {
+			return "", err
+		}
+
+		if tmpl, err = tmpl.Parse(string(content)); err != nil {
+			return "", err
+		}
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&body, layoutName, data); err != nil {
+		return "", err
+	}
+
+	return body.String(), nil
+}
+
+func (s *templateService) renderText(name string, locale string, translate func(key string, args ...interface{}) string, data map[string]interface{}) (string, error) {
+	tmpl := textTemplate.New(name).Funcs(textTemplate.FuncMap{"t": translate})
+	for _, file := range []string{layoutName + ".txt", s.variant(name, locale, ".txt")} {
+		content, err := s.read(file)
+		if err != nil {
+			return "", err
+		}
+
+		if tmpl, err = tmpl.Parse(string(content)); err != nil {
+			return "", err
+		}
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&body, layoutName, data); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(body.String()) + "\n", nil
+}
+
+// variant returns <name>.<locale><ext> when it exists and <name><ext> otherwise
+func (s *templateService) variant(name string, locale string, ext string) string {
+	localized := name + "." + locale + ext
+	if _, err := s.read(localized); err == nil {
+		return localized
+	}
+
+	return name + ext
+}
+
+// translator looks messages up in the locale's catalog, then in the default one.
+// A missing message renders as its key so it is easy to spot.
+func (s *templateService) translator(locale string) func(key string, args ...interface{}) string {
+	catalogs := []map[string]string{s.catalog(locale)}
+	if locale != DefaultLocale {
+		catalogs = append(catalogs, s.catalog(DefaultLocale))
+	}
+
+	return func(key string, args ...interface{}) string {
+		for _, catalog := range catalogs {
+			if message, ok := catalog[key]; ok {
+				if len(args) == 0 {
+					return message
+				}
+
+				return fmt.Sprintf(message, args...)
+			}
+		}
+
+		return key
+	}
+}
+
+// catalog returns the locale's messages, nil when there is no catalog or it can't be read
+func (s *templateService) catalog(locale string) map[string]string {
+	content, err := s.read(path.Join(localesDir, locale+".yaml"))
+	if err != nil {
+		return nil
+	}
+
+	var messages map[string]string
+	if err := yaml.Unmarshal(content, &messages); err != nil {
+		return nil
+	}
+
+	return messages
+}
+
+// findLocales lists the built-in and override catalogs, the default locale first
+func (s *templateService) findLocales() []string {
+	found := map[string]bool{DefaultLocale: true}
+
+	addLocales := func(fsys fs.FS) {
+		matches, _ := fs.Glob(fsys, path.Join(localesDir, "*.yaml"))
+		for _, match := range matches {
+			found[strings.TrimSuffix(path.Base(match), ".yaml")] = true
+		}
+	}
+
+	addLocales(s.embedded)
+	if s.overrideDir != "" {
+		addLocales(os.DirFS(s.overrideDir))
+	}
+
+	delete(found, DefaultLocale)
+	locales := make([]string, 0, len(found))
+	for locale := range found {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	return append([]string{DefaultLocale}, locales...)
+}
+
+func (s *templateService) read(name string) ([]byte, error) {
+	if s.overrideDir != "" {
+		content, err := os.ReadFile(filepath.Join(s.overrideDir, filepath.FromSlash(name)))
+		if err == nil {
+			return content, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return fs.ReadFile(s.embedded, name)
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/winartodev/apollo-be/config"
+)
+
+var (
+	errInvalidKey = errors.New("invalid storage key")
+)
+
+// localStorage stores files on the local disk
+type localStorage struct {
+	directory string
+	baseURL   string
+}
+
+// NewLocalStorage creates a new local disk storage instance
+func NewLocalStorage(localConfig *config.LocalStorage) (Storage, error) {
+	directory := localConfig.GetDirectory()
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &localStorage{
+		directory: directory,
+		baseURL:   strings.TrimRight(localConfig.GetBaseURL(), "/"),
+	}, nil
+}
+
+// Put writes the file to a temporary path first and renames it, so readers never see partial files
+func (s *localStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store file: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the file, missing files are ignored
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+// URL returns the public URL of the file
+func (s *localStorage) URL(key string) string {
+	return s.baseURL + "/" + strings.TrimLeft(key, "/")
+}
+
+func (s *localStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" {
+		return "", errInvalidKey
+	}
+
+	return filepath.Join(s.directory, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/winartodev/apollo-be/config"
+)
+
+// s3Storage stores files in an S3 compatible object storage such as AWS S3 or MinIO
+type s3Storage struct {
+	client  *minio.Client
+	bucket  string
+	baseURL string
+}
+
+// NewS3Storage creates a new S3 compatible storage instance
+func NewS3Storage(s3Config *config.S3Storage) (Storage, error) {
+	client, err := minio.New(s3Config.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(s3Config.AccessKey, s3Config.SecretKey, ""),
+		Secure: s3Config.UseSSL,
+		Region: s3Config.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	baseURL := s3Config.BaseURL
+	if baseURL == "" {
+		scheme := "http"
+		if s3Config.UseSSL {
+			scheme = "https"
+		}
+
+		baseURL = fmt.Sprintf("%s://%s/%s", scheme, s3Config.Endpoint, s3Config.Bucket)
+	}
+
+	return &s3Storage{
+		client:  client,
+		bucket:  s3Config.Bucket,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+// Put uploads the object to the bucket
+func (s *s3Storage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, reader, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: "public, max-age=31536000, immutable",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the object from the bucket
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+// URL returns the public URL of the object
+func (s *s3Storage) URL(key string) string {
+	return s.baseURL + "/" + strings.TrimLeft(key, "/")
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/winartodev/apollo-be/config"
+)
+
+// The S3 tests run against an S3 compatible server when APOLLO_TEST_S3_ENDPOINT is set, e.g. a local MinIO with
+// APOLLO_TEST_S3_ENDPOINT=localhost:9000 APOLLO_TEST_S3_ACCESS_KEY=minioadmin APOLLO_TEST_S3_SECRET_KEY=minioadmin.
+// Every test creates its own bucket and removes it afterwards.
+const (
+	testS3EndpointEnv  = "APOLLO_TEST_S3_ENDPOINT"
+	testS3AccessKeyEnv = "APOLLO_TEST_S3_ACCESS_KEY"
+	testS3SecretKeyEnv = "APOLLO_TEST_S3_SECRET_KEY"
+	testS3UseSSLEnv    = "APOLLO_TEST_S3_USE_SSL"
+)
+
+// newTestS3Storage creates a throwaway bucket and a storage using it
+func newTestS3Storage(t *testing.T) *s3Storage {
+	t.Helper()
+
+	endpoint := os.Getenv(testS3EndpointEnv)
+	if endpoint == "" {
+		t.Skipf("%s is not set", testS3EndpointEnv)
+	}
+
+	s3Config := &config.S3Storage{
+		Endpoint:  endpoint,
+		Bucket:    fmt.Sprintf("apollo-storage-test-%d", time.Now().UnixNano()),
+		AccessKey: os.Getenv(testS3AccessKeyEnv),
+		SecretKey: os.Getenv(testS3SecretKeyEnv),
+		UseSSL:    os.Getenv(testS3UseSSLEnv) == "true",
+	}
+
+	storage, err := NewS3Storage(s3Config)
+	if err != nil {
+		t.Fatalf("NewS3Storage() error = %v", err)
+	}
+
+	s3 := storage.(*s3Storage)
+	ctx := context.Background()
+
+	if err := s3.client.MakeBucket(ctx, s3Config.Bucket, minio.MakeBucketOptions{}); err != nil {
+		t.Fatalf("failed to create the test bucket: %v", err)
+	}
+
+	t.Cleanup(func() {
+		for object := range s3.client.ListObjects(ctx, s3Config.Bucket, minio.ListObjectsOptions{Recursive: true}) {
+			if object.Err == nil {
+				_ = s3.client.RemoveObject(ctx, s3Config.Bucket, object.Key, minio.RemoveObjectOptions{})
+			}
+		}
+
+		if err := s3.client.RemoveBucket(ctx, s3Config.Bucket); err != nil {
+			t.Errorf("failed to remove the test bucket: %v", err)
+		}
+	})
+
+	return s3
+}
+
+func TestS3StoragePutAndDelete(t *testing.T) {
+	s3 := newTestS3Storage(t)
+	ctx := context.Background()
+
+	key := "avatars/1/1/small.jpg"
+	data := []byte("not really a jpeg")
+
+	if err := s3.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	object, err := s3.client.GetObject(ctx, s3.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		t.Fatalf("GetObject() error = %v", err)
+	}
+
+	defer object.Close()
+
+	stored, err := io.ReadAll(object)
+	if err != nil {
+		t.Fatalf("failed to read the stored object: %v", err)
+	}
+
+	if !bytes.Equal(stored, data) {
+		t.Errorf("stored object = %q, want %q", stored, data)
+	}
+
+	info, err := object.Stat()
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	if info.ContentType != "image/jpeg" {
+		t.Errorf("content type = %q, want %q", info.ContentType, "image/jpeg")
+	}
+
+	if cacheControl := info.Metadata.Get("Cache-Control"); cacheControl != "public, max-age=31536000, immutable" {
+		t.Errorf("cache control = %q, want objects to be cached as immutable", cacheControl)
+	}
+
+	if err := s3.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	_, err = s3.client.StatObject(ctx, s3.bucket, key, minio.StatObjectOptions{})
+	if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		t.Errorf("StatObject() after Delete error = %v, want NoSuchKey", err)
+	}
+
+	// Deleting a missing object succeeds, so a partial upload can always be cleaned up
+	if err := s3.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of a missing object error = %v", err)
+	}
+}
+
+func TestS3StoragePutFailsWithoutBucket(t *testing.T) {
+	s3 := newTestS3Storage(t)
+	s3.bucket += "-missing"
+
+	data := []byte("data")
+	if err := s3.Put(context.Background(), "key", bytes.NewReader(data), int64(len(data)), "text/plain"); err == nil {
+		t.Fatal("Put() error = nil, want an error for a missing bucket")
+	}
+}
+
+func TestS3StorageURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.S3Storage
+		key    string
+		want   string
+	}{
+		{
+			name:   "endpoint",
+			config: config.S3Storage{Endpoint: "localhost:9000", Bucket: "avatars"},
+			key:    "avatars/1/small.jpg",
+			want:   "http://localhost:9000/avatars/avatars/1/small.jpg",
+		},
+		{
+			name:   "endpoint with ssl",
+			config: config.S3Storage{Endpoint: "s3.example.com", Bucket: "avatars", UseSSL: true},
+			key:    "/a.jpg",
+			want:   "https://s3.example.com/avatars/a.jpg",
+		},
+		{
+			name:   "base url",
+			config: config.S3Storage{Endpoint: "s3.example.com", Bucket: "avatars", BaseURL: "https://cdn.example.com/"},
+			key:    "a.jpg",
+			want:   "https://cdn.example.com/a.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := NewS3Storage(&tt.config)
+			if err != nil {
+				t.Fatalf("NewS3Storage() error = %v", err)
+			}
+
+			if got := storage.URL(tt.key); got != tt.want {
+				t.Errorf("URL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
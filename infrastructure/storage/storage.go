@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/winartodev/apollo-be/config"
+)
+
+const (
+	errorUnsupportedDriver = "unsupported storage driver: %s"
+)
+
+// Storage defines the interface for storing files
+type Storage interface {
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// NewStorage creates the storage implementation selected by the config driver
+func NewStorage(storageConfig *config.Storage) (Storage, error) {
+	switch storageConfig.Driver {
+	case config.StorageDriverLocal, "":
+		return NewLocalStorage(&storageConfig.Local)
+	case config.StorageDriverS3:
+		return NewS3Storage(&storageConfig.S3)
+	default:
+		return nil, fmt.Errorf(errorUnsupportedDriver, storageConfig.Driver)
+	}
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type AccountEventType string
+
+const (
+	AccountSignedUp               AccountEventType = "signed_up"
+	AccountSignedIn               AccountEventType = "signed_in"
+	AccountSignedOut              AccountEventType = "signed_out"
+	AccountTokenRefreshed         AccountEventType = "token_refreshed"
+	AccountOtpSent                AccountEventType = "otp_sent"
+	AccountOtpValidated           AccountEventType = "otp_validated"
+	AccountPasswordChanged        AccountEventType = "password_changed"
+	AccountPasswordResetRequested AccountEventType = "password_reset_requested"
+	AccountLocked                 AccountEventType = "account_locked"
+	AccountActivityReported       AccountEventType = "activity_reported"
+	AccountCreated                AccountEventType = "account_created"
+	AccountDeactivated            AccountEventType = "account_deactivated"
+)
+
+// AccountEventResult tells whether the attempt the event records succeeded
+type AccountEventResult string
+
+const (
+	EventSucceeded AccountEventResult = "success"
+	EventFailed    AccountEventResult = "failure"
+)
+
+// ActorType is who caused an account event
+type ActorType string
+
+const (
+	// ActorUser is a signed in user or one signing in, the account's owner unless ActorID says otherwise
+	ActorUser ActorType = "user"
+	// ActorOperator is an operator using the command line, there is no actor ID
+	ActorOperator ActorType = "operator"
+)
+
+// Metadata keys of account events
+const (
+	EventMetadataLockedUntil = "locked_until" // account_locked, RFC 3339
+	EventMetadataIdentifier  = "identifier"   // signed_in, the username or email of a failed attempt
+	EventMetadataReason      = "reason"       // failed events, the error
+)
+
+// AccountEvent is an authentication attempt or a change to an account
+type AccountEvent struct {
+	Type AccountEventType
+	// UserID is the account, zero when it isn't known, e.g. a sign-in with an unknown username
+	UserID int64
+	// Actor defaults to ActorUser, and ActorID to the signed in user or else UserID
+	Actor      ActorType
+	ActorID    int64
+	Result     AccountEventResult // defaults to EventSucceeded
+	IP         string
+	UserAgent  string
+	RequestID  string
+	OccurredAt time.Time
+	Metadata   map[string]string
+}
+
+// EventPublisher delivers account events to their subscribers before returning,
+// so work they do joins the caller's transaction and is rolled back with it
+type EventPublisher interface {
+	Publish(ctx context.Context, event AccountEvent) error
+}
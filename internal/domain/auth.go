@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	domainEntity "github.com/winartodev/apollo-be/internal/domain/entities"
@@ -12,11 +13,12 @@ type TokenPair struct {
 }
 
 type TokenClaims struct {
-	UserID    int64
-	Username  string
-	Email     string
-	IssueAt   time.Time
-	ExpiresAt time.Time
+	UserID       int64
+	Username     string
+	Email        string
+	TokenVersion int64
+	IssueAt      time.Time
+	ExpiresAt    time.Time
 }
 
 type TokenService interface {
@@ -30,3 +32,23 @@ type PasswordService interface {
 	HashPassword(password string) (string, error)
 	ComparePassword(password, hash string) bool
 }
+
+// UserStatusService reports whether a user is still allowed to authenticate, whether their tokens were revoked,
+// and whether they are an admin.
+type UserStatusService interface {
+	IsUserAdmin(ctx context.Context, userID int64) (bool, error)
+	// AuthorizeToken returns ErrUserInactive when the user can't authenticate anymore,
+	// and ErrTokenRevoked when the token was issued before the user's tokens were revoked
+	AuthorizeToken(ctx context.Context, userID int64, tokenVersion int64) error
+	// GetTokenVersion returns the version new tokens of the user are issued with
+	GetTokenVersion(ctx context.Context, userID int64) (int64, error)
+	// InvalidateUserStatus drops the cached status, call it after a change to the user is committed
+	InvalidateUserStatus(ctx context.Context, userID int64) error
+}
+
+// APIKeyService issues and verifies the API keys used by machine clients.
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, name string) (key string, res *domainEntity.APIKey, err error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	VerifyAPIKey(ctx context.Context, key string) (bool, error)
+}
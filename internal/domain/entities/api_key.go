@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+// APIKey is an API key issued to a machine client, only a hash of the key itself is stored
+type APIKey struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
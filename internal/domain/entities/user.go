@@ -16,11 +16,14 @@ type SharedUser struct {
 
 	// Authentication
 	Password string `json:"-"` // Never serialize password
+	// TokenVersion is carried by issued tokens, it is bumped to revoke all of them
+	TokenVersion int64 `json:"-"`
 
 	// Status fields
 	IsActive        bool `json:"is_active"`
 	IsEmailVerified bool `json:"is_email_verified"`
 	IsPhoneVerified bool `json:"is_phone_verified"`
+	IsAdmin         bool `json:"is_admin"`
 
 	// Timestamps
 	LastLogin *time.Time `json:"last_login,omitempty"`
@@ -43,6 +46,11 @@ func (u *SharedUser) IsDeleted() bool {
 	return u.DeletedAt != nil
 }
 
+// CanAuthenticate checks if the user is active and not soft deleted
+func (u *SharedUser) CanAuthenticate() bool {
+	return u.IsActive && !u.IsDeleted()
+}
+
 // GetFullName returns the user's full name
 func (u *SharedUser) GetFullName() string {
 	if u.FirstName != "" && u.LastName != "" {
@@ -24,6 +24,44 @@ var (
 	ErrEmptyToken                   = errors.New("empty_token")
 	ErrPasswordConfirmationMismatch = errors.New("password_confirmation_mismatch")
 	ErrFailedUpdatePassword         = errors.New("password_changed_failed")
+	ErrUserInactive                 = errors.New("user_inactive")
+	ErrFailedUpdateLastLogin        = errors.New("failed_update_last_login")
+	ErrInvalidAvatarFile            = errors.New("invalid_avatar_file")
+	ErrAvatarTooLarge               = errors.New("avatar_too_large")
+	ErrUnsupportedAvatarType        = errors.New("unsupported_avatar_type")
+	ErrFailedUploadAvatar           = errors.New("failed_upload_avatar")
+	ErrInvalidPreference            = errors.New("invalid_preference")
+	ErrOtpChannelUnavailable        = errors.New("otp_channel_unavailable")
+	ErrFailedGetPreferences         = errors.New("failed_get_preferences")
+	ErrFailedUpdatePreferences      = errors.New("failed_update_preferences")
+	ErrRegistrationClosed           = errors.New("registration_closed")
+	ErrInvitationRequired           = errors.New("invitation_required")
+	ErrInvalidInvitation            = errors.New("invalid_invitation")
+	ErrEmailDomainNotAllowed        = errors.New("email_domain_not_allowed")
+	ErrInviteQuotaExceeded          = errors.New("invite_quota_exceeded")
+	ErrInvitationsDisabled          = errors.New("invitations_disabled")
+	ErrFailedCreateInvitation       = errors.New("failed_create_invitation")
+	ErrFailedGetInvitation          = errors.New("failed_get_invitation")
+	ErrInvalidPhoneNumber           = errors.New("invalid_phone_number")
+	ErrPhoneNumberAlreadyExists     = errors.New("phone_number_already_exists")
+	ErrCountryNotFound              = errors.New("country_not_found")
+	ErrInvalidCountryCode           = errors.New("invalid_country_code")
+	ErrInvalidCountryField          = errors.New("invalid_country_field")
+	ErrFailedGetCountries           = errors.New("failed_get_countries")
+	ErrFailedSyncCountries          = errors.New("failed_sync_countries")
+	ErrInvalidAPIKey                = errors.New("invalid_api_key")
+	ErrAPIKeyNotFound               = errors.New("api_key_not_found")
+	ErrFailedCreateAPIKey           = errors.New("failed_create_api_key")
+	ErrFailedDeactivateUser         = errors.New("failed_deactivate_user")
+	ErrTooManyRequests              = errors.New("too_many_requests")
+	ErrInvalidReportToken           = errors.New("invalid_report_token")
+	ErrFailedRecordSignIn           = errors.New("failed_record_sign_in")
+	ErrAdminRequired                = errors.New("admin_required")
+	ErrInvalidAuditFilter           = errors.New("invalid_audit_filter")
+	ErrFailedRecordAuditEvent       = errors.New("failed_record_audit_event")
+	ErrFailedGetAuditEvents         = errors.New("failed_get_audit_events")
+	ErrTokenRevoked                 = errors.New("token_revoked")
+	ErrFailedRevokeTokens           = errors.New("failed_revoke_tokens")
 )
 
 // ErrorCodeMapping pairs an error with an HTTP status code
@@ -48,11 +86,49 @@ var errorMappings = []ErrorCodeMapping{
 	{ErrEmptyToken, http.StatusUnauthorized},
 	{ErrPasswordConfirmationMismatch, http.StatusBadRequest},
 	{ErrInvalidOTPCode, http.StatusUnauthorized},
+	{ErrUserInactive, http.StatusForbidden},
+	{ErrInvalidAvatarFile, http.StatusBadRequest},
+	{ErrAvatarTooLarge, http.StatusRequestEntityTooLarge},
+	{ErrUnsupportedAvatarType, http.StatusUnsupportedMediaType},
+	{ErrInvalidPreference, http.StatusBadRequest},
+	{ErrOtpChannelUnavailable, http.StatusUnprocessableEntity},
+	{ErrRegistrationClosed, http.StatusForbidden},
+	{ErrInvitationRequired, http.StatusForbidden},
+	{ErrInvalidInvitation, http.StatusBadRequest},
+	{ErrEmailDomainNotAllowed, http.StatusForbidden},
+	{ErrInviteQuotaExceeded, http.StatusForbidden},
+	{ErrInvitationsDisabled, http.StatusForbidden},
+	{ErrInvalidPhoneNumber, http.StatusBadRequest},
+	{ErrPhoneNumberAlreadyExists, http.StatusConflict},
+	{ErrCountryNotFound, http.StatusNotFound},
+	{ErrInvalidCountryCode, http.StatusBadRequest},
+	{ErrInvalidCountryField, http.StatusBadRequest},
+	{ErrInvalidAPIKey, http.StatusUnauthorized},
+	{ErrAPIKeyNotFound, http.StatusNotFound},
+	{ErrTooManyRequests, http.StatusTooManyRequests},
+	{ErrInvalidReportToken, http.StatusBadRequest},
+	{ErrAdminRequired, http.StatusForbidden},
+	{ErrInvalidAuditFilter, http.StatusBadRequest},
+	{ErrTokenRevoked, http.StatusUnauthorized},
 
 	// For generic internal failures, map to 500
 	{ErrFailedCreateUser, http.StatusInternalServerError},
 	{ErrFailedUpdateRefreshToken, http.StatusInternalServerError},
+	{ErrFailedRevokeTokens, http.StatusInternalServerError},
 	{ErrFailedGetUserData, http.StatusInternalServerError},
+	{ErrFailedUpdateLastLogin, http.StatusInternalServerError},
+	{ErrFailedUploadAvatar, http.StatusInternalServerError},
+	{ErrFailedGetPreferences, http.StatusInternalServerError},
+	{ErrFailedUpdatePreferences, http.StatusInternalServerError},
+	{ErrFailedCreateInvitation, http.StatusInternalServerError},
+	{ErrFailedGetInvitation, http.StatusInternalServerError},
+	{ErrFailedGetCountries, http.StatusInternalServerError},
+	{ErrFailedSyncCountries, http.StatusInternalServerError},
+	{ErrFailedCreateAPIKey, http.StatusInternalServerError},
+	{ErrFailedDeactivateUser, http.StatusInternalServerError},
+	{ErrFailedRecordSignIn, http.StatusInternalServerError},
+	{ErrFailedRecordAuditEvent, http.StatusInternalServerError},
+	{ErrFailedGetAuditEvents, http.StatusInternalServerError},
 }
 
 // GetHTTPStatusFromError returns the HTTP status code for a given error.
@@ -70,3 +146,14 @@ func GetHTTPStatusFromError(err error) int {
 
 	return http.StatusInternalServerError
 }
+
+// IsDomainError reports whether err is, or wraps, one of the errors mapped above.
+func IsDomainError(err error) bool {
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.Target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,10 @@
+package domain
+
+// Metrics records domain events, the implementation decides how they are exported.
+type Metrics interface {
+	SignUp()
+	SignInFailed(reason string)
+	OtpSent(method string)
+	OtpValidated(valid bool)
+	TokenRefreshed()
+}
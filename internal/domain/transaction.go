@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+// Transactor runs fn in a database transaction, repositories called with the ctx passed to fn join it.
+// The transaction is rolled back when fn returns an error.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithSavepoint runs fn in a savepoint of the caller's transaction, or in a transaction of its own.
+	// A failure of fn only rolls back its own work, the caller's transaction can still commit.
+	WithSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_users_invited_by;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS invitation_id,
+    DROP COLUMN IF EXISTS invited_by,
+    DROP COLUMN IF EXISTS invite_quota,
+    DROP COLUMN IF EXISTS is_admin;
+
+DROP INDEX IF EXISTS idx_invitations_inviter_id;
+
+DROP TABLE IF EXISTS invitations;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS invitations
+(
+    id         SERIAL PRIMARY KEY,
+    code       VARCHAR(64)  NOT NULL UNIQUE,
+    inviter_id INTEGER      NULL REFERENCES users (id) ON DELETE SET NULL,
+    email      VARCHAR(255) NULL,
+    max_uses   INTEGER      NOT NULL DEFAULT 1,
+    used_count INTEGER      NOT NULL DEFAULT 0,
+    expires_at BIGINT       NOT NULL,
+    created_at BIGINT       NOT NULL,
+    revoked_at BIGINT       NULL
+);
+
+CREATE INDEX idx_invitations_inviter_id ON invitations (inviter_id);
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS is_admin      BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS invite_quota  INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS invited_by    INTEGER NULL REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS invitation_id INTEGER NULL REFERENCES invitations (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_users_invited_by ON users (invited_by);
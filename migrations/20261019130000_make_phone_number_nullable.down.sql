@@ -0,0 +1,32 @@
+-- This migration is only reversible while at most one user has no phone number. The numbers from before the up
+-- migration are restored from users_phone_number_backup first, but users who signed up without a number since then
+-- have nothing to restore, NOT NULL UNIQUE can't hold more than one of them and the migration fails instead.
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_phone_number_e164,
+    DROP CONSTRAINT IF EXISTS users_phone_number_key;
+
+UPDATE users AS usr
+SET phone_number      = backup.phone_number,
+    is_phone_verified = backup.is_phone_verified
+FROM users_phone_number_backup AS backup
+WHERE usr.id = backup.user_id;
+
+-- Users without a number get the empty string back, the UNIQUE constraint only allows one of them
+DO
+$$
+BEGIN
+    IF (SELECT COUNT(*) FROM users WHERE phone_number IS NULL OR phone_number = '') > 1 THEN
+        RAISE EXCEPTION 'more than one user has no phone number, phone_number can''t be made NOT NULL UNIQUE again';
+    END IF;
+END
+$$;
+
+UPDATE users
+SET phone_number = ''
+WHERE phone_number IS NULL;
+
+ALTER TABLE users
+    ALTER COLUMN phone_number SET NOT NULL,
+    ADD CONSTRAINT users_phone_number_key UNIQUE (phone_number);
+
+DROP TABLE IF EXISTS users_phone_number_backup;
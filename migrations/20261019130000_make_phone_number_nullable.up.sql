@@ -0,0 +1,49 @@
+-- The original numbers are kept so the down migration can restore them
+CREATE TABLE IF NOT EXISTS users_phone_number_backup
+(
+    user_id           INTEGER     PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    phone_number      VARCHAR(16) NOT NULL,
+    is_phone_verified BOOLEAN     NOT NULL
+);
+
+INSERT INTO users_phone_number_backup (user_id, phone_number, is_phone_verified)
+SELECT id, phone_number, is_phone_verified
+FROM users
+ON CONFLICT (user_id) DO NOTHING;
+
+-- The constraint is added again once the numbers are normalized and unique
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_phone_number_key,
+    ALTER COLUMN phone_number DROP NOT NULL;
+
+-- Sign-ups without a phone number stored an empty string, which collides with the UNIQUE constraint.
+-- Separators are removed and a 00 international prefix becomes +, so formatted numbers become E.164.
+UPDATE users
+SET phone_number = NULLIF(regexp_replace(regexp_replace(phone_number, '[[:space:]().-]', '', 'g'), '^00', '+'), '');
+
+-- National numbers start with the trunk prefix 0, they get the calling code of the default region, phone.defaultRegion ID
+UPDATE users
+SET phone_number = regexp_replace(phone_number, '^0', '+62')
+WHERE phone_number ~ '^0[1-9][0-9]*$';
+
+-- Only numbers that still can't be read as E.164 are cleared, the backup keeps them
+UPDATE users
+SET phone_number      = NULL,
+    is_phone_verified = FALSE
+WHERE phone_number !~ '^\+[1-9][0-9]{1,14}$';
+
+-- Numbers that were stored in different formats are now equal, the verified one, then the oldest account, keeps it
+UPDATE users AS usr
+SET phone_number      = NULL,
+    is_phone_verified = FALSE
+FROM (SELECT id,
+             ROW_NUMBER() OVER (PARTITION BY phone_number ORDER BY is_phone_verified DESC, id) AS position
+      FROM users
+      WHERE phone_number IS NOT NULL) AS ranked
+WHERE usr.id = ranked.id
+  AND ranked.position > 1;
+
+-- New numbers are stored in E.164 by the application
+ALTER TABLE users
+    ADD CONSTRAINT users_phone_number_key UNIQUE (phone_number),
+    ADD CONSTRAINT users_phone_number_e164 CHECK (phone_number ~ '^\+[1-9][0-9]{1,14}$');
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_users_created_at;
+
+ALTER TABLE invitations
+    ALTER COLUMN expires_at TYPE BIGINT USING EXTRACT(EPOCH FROM expires_at)::BIGINT,
+    ALTER COLUMN created_at DROP DEFAULT,
+    ALTER COLUMN created_at TYPE BIGINT USING EXTRACT(EPOCH FROM created_at)::BIGINT,
+    ALTER COLUMN revoked_at TYPE BIGINT USING EXTRACT(EPOCH FROM revoked_at)::BIGINT;
+
+ALTER TABLE users
+    ALTER COLUMN last_login TYPE BIGINT USING EXTRACT(EPOCH FROM last_login)::BIGINT,
+    ALTER COLUMN created_at DROP DEFAULT,
+    ALTER COLUMN created_at TYPE BIGINT USING EXTRACT(EPOCH FROM created_at)::BIGINT,
+    ALTER COLUMN updated_at TYPE BIGINT USING EXTRACT(EPOCH FROM updated_at)::BIGINT,
+    ALTER COLUMN deleted_at TYPE BIGINT USING EXTRACT(EPOCH FROM deleted_at)::BIGINT;
@@ -0,0 +1,15 @@
+-- Unix seconds become timestamptz, to_timestamp backfills every row in place and keeps NULLs.
+ALTER TABLE users
+    ALTER COLUMN last_login TYPE TIMESTAMPTZ USING to_timestamp(last_login),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING to_timestamp(created_at),
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING to_timestamp(updated_at),
+    ALTER COLUMN deleted_at TYPE TIMESTAMPTZ USING to_timestamp(deleted_at);
+
+ALTER TABLE invitations
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING to_timestamp(expires_at),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING to_timestamp(created_at),
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN revoked_at TYPE TIMESTAMPTZ USING to_timestamp(revoked_at);
+
+CREATE INDEX idx_users_created_at ON users (created_at);
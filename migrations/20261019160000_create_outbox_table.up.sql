@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id            BIGSERIAL PRIMARY KEY,
+    kind          VARCHAR(50) NOT NULL,
+    payload       JSONB       NOT NULL,
+    status        VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts      INT         NOT NULL DEFAULT 0,
+    run_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until  TIMESTAMPTZ NULL,
+    last_error    TEXT        NULL,
+    -- trace_context links the job's span to the request that enqueued it
+    trace_context JSONB       NOT NULL DEFAULT '{}',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (run_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_running ON outbox (locked_until) WHERE status = 'running';
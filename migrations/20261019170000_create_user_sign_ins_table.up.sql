@@ -0,0 +1,10 @@
+-- Every IP and user agent a user signed in from, a sign-in from one not seen before is notified
+CREATE TABLE IF NOT EXISTS user_sign_ins
+(
+    user_id       INTEGER      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    ip            VARCHAR(45)  NOT NULL,
+    user_agent    VARCHAR(512) NOT NULL,
+    first_seen_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    last_seen_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, ip, user_agent)
+);
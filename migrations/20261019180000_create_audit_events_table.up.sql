@@ -0,0 +1,33 @@
+-- Append-only record of authentication and account events. Rows are never updated, only deleted once they
+-- are older than audit.retention. There is no foreign key to users so events outlive the accounts.
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id         BIGSERIAL PRIMARY KEY,
+    actor_type VARCHAR(16)  NOT NULL,
+    actor_id   BIGINT       NULL,
+    user_id    BIGINT       NULL,
+    action     VARCHAR(50)  NOT NULL,
+    result     VARCHAR(16)  NOT NULL,
+    ip         VARCHAR(45)  NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    request_id VARCHAR(64)  NOT NULL DEFAULT '',
+    metadata   JSONB        NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events (user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events (actor_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_update
+    BEFORE UPDATE
+    ON audit_events
+    FOR EACH ROW
+EXECUTE FUNCTION audit_events_append_only();
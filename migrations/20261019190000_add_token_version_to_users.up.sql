@@ -0,0 +1,3 @@
+-- Tokens carry the version they were issued with, bumping it rejects every access and refresh token of the user
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS token_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/winartodev/apollo-be/infrastructure/http/response"
+	"github.com/winartodev/apollo-be/infrastructure/middleware"
+	"github.com/winartodev/apollo-be/modules/audit/delivery/http/dto"
+	"github.com/winartodev/apollo-be/modules/audit/usecase"
+	useCaseDto "github.com/winartodev/apollo-be/modules/audit/usecase/dto"
+)
+
+type AuditHandler struct {
+	middleware   *middleware.Middleware
+	auditUseCase usecase.AuditUseCase
+}
+
+func NewAuditHandler(auditUseCase usecase.AuditUseCase, middleware *middleware.Middleware) *AuditHandler {
+	return &AuditHandler{
+		middleware:   middleware,
+		auditUseCase: auditUseCase,
+	}
+}
+
+// GetActivity godoc
+//
+//	@Summary		Get activity
+//	@Description	List the sign-ins, password changes and other events of the current user's account, newest first
+//	@Tags			User
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int																					false	"Page, defaults to 1"
+//	@Param			per_page	query		int																					false	"Events per page, defaults to 20, at most 100"
+//	@Success		200			{object}	response.Response{data=response.PaginateResponse{data=[]dto.AuditEventResponse}}	"Account activity"
+//	@Failure		401			{object}	response.ErrorResponse																"Unauthorized"
+//	@Failure		422			{object}	response.ErrorResponse																"Validation error"
+//	@Failure		500			{object}	response.ErrorResponse																"Internal server error"
+//	@Router			/users/me/activity [get]
+func (ah *AuditHandler) GetActivity(c echo.Context) error {
+	var req dto.ActivityQueryRequest
+
+	if err := c.Bind(&req); err != nil {
+		return response.FailedResponse(c, http.StatusBadRequest, fmt.Errorf(response.ErrInvalidRequestPayload, err))
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.ValidationErrResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	res, err := ah.auditUseCase.GetCurrentUserActivity(ctx, useCaseDto.NewPageDto(req.Page, req.PerPage))
+	if err != nil {
+		return response.FailedResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return response.SuccessResponse(c, http.StatusOK, "OK", res.ToResponse(), nil)
+}
+
+// GetAuditEvents godoc
+//
+//	@Summary		List audit events
+//	@Description	List the authentication and account events of every user, newest first. Admins only, services send an API key instead.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-API-Key	header		string																				false	"API key, used instead of an admin's bearer token"
+//	@Param			user_id		query		int																					false	"Account the event is about"
+//	@Param			actor_id	query		int																					false	"User who caused the event"
+//	@Param			action		query		string																				false	"Action, e.g. signed_in or password_changed"
+//	@Param			result		query		string																				false	"success or failure"
+//	@Param			ip			query		string																				false	"Client IP address"
+//	@Param			from		query		string																				false	"RFC 3339 time, inclusive"
+//	@Param			to			query		string																				false	"RFC 3339 time, exclusive"
+//	@Param			page		query		int																					false	"Page, defaults to 1"
+//	@Param			per_page	query		int																					false	"Events per page, defaults to 20, at most 100"
+//	@Success		200			{object}	response.Response{data=response.PaginateResponse{data=[]dto.AuditEventResponse}}	"Audit events"
+//	@Failure		400			{object}	response.ErrorResponse																"Invalid filter"
+//	@Failure		401			{object}	response.ErrorResponse																"Unauthorized"
+//	@Failure		403			{object}	response.ErrorResponse																"Not an admin"
+//	@Failure		422			{object}	response.ErrorResponse																"Validation error"
+//	@Failure		500			{object}	response.ErrorResponse																"Internal server error"
+//	@Router			/admin/audit-events [get]
+func (ah *AuditHandler) GetAuditEvents(c echo.Context) error {
+	var req dto.AuditEventQueryRequest
+
+	if err := c.Bind(&req); err != nil {
+		return response.FailedResponse(c, http.StatusBadRequest, fmt.Errorf(response.ErrInvalidRequestPayload, err))
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.ValidationErrResponse(c, err)
+	}
+
+	filter, err := useCaseDto.NewAuditFilterDto(req)
+	if err != nil {
+		return response.FailedResponse(c, http.StatusBadRequest, err)
+	}
+
+	ctx := c.Request().Context()
+	res, err := ah.auditUseCase.GetEvents(ctx, filter)
+	if err != nil {
+		return response.FailedResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return response.SuccessResponse(c, http.StatusOK, "OK", res.ToResponse(), nil)
+}
+
+func (ah *AuditHandler) RegisterRoutes(api *echo.Group) error {
+	api.GET("/users/me/activity", ah.GetActivity, ah.middleware.HandleWithAuth())
+	api.GET("/admin/audit-events", ah.GetAuditEvents, ah.middleware.HandleWithAdminOrAPIKey())
+
+	return nil
+}
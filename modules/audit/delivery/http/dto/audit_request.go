@@ -0,0 +1,20 @@
+package dto
+
+// ActivityQueryRequest represents the page of the current user's activity
+type ActivityQueryRequest struct {
+	Page    int `query:"page" validate:"min=0"`
+	PerPage int `query:"per_page" validate:"min=0,max=100"`
+}
+
+// AuditEventQueryRequest represents the filters of the audit event list
+type AuditEventQueryRequest struct {
+	UserID  int64  `query:"user_id" validate:"min=0"`
+	ActorID int64  `query:"actor_id" validate:"min=0"`
+	Action  string `query:"action"`
+	Result  string `query:"result" validate:"omitempty,oneof=success failure"`
+	IP      string `query:"ip"`
+	From    string `query:"from"`
+	To      string `query:"to"`
+	Page    int    `query:"page" validate:"min=0"`
+	PerPage int    `query:"per_page" validate:"min=0,max=100"`
+}
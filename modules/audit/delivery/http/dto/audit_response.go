@@ -0,0 +1,27 @@
+package dto
+
+// AuditEventResponse represents an authentication or account event
+// swagger:model AuditEventResponse
+type AuditEventResponse struct {
+	ID int64 `json:"id"`
+
+	// Who caused the event, user or operator
+	ActorType string `json:"actor_type" example:"user"`
+
+	// The user who caused the event, empty for operators
+	ActorID *int64 `json:"actor_id,omitempty"`
+
+	// The account the event is about, empty when it isn't known
+	UserID *int64 `json:"user_id,omitempty"`
+
+	Action string `json:"action" example:"signed_in"`
+
+	// success or failure
+	Result string `json:"result" example:"success"`
+
+	IP        string            `json:"ip" example:"203.0.113.7"`
+	UserAgent string            `json:"user_agent"`
+	RequestID string            `json:"request_id,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt string            `json:"created_at" example:"2026-10-19T08:30:00Z"`
+}
@@ -0,0 +1,57 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/modules/audit/usecase"
+)
+
+type AuditPruneJob struct {
+	auditUseCase usecase.AuditUseCase
+	interval     time.Duration
+	logger       *slog.Logger
+}
+
+func NewAuditPruneJob(auditUseCase usecase.AuditUseCase, auditConfig *config.Audit, appLogger *logger.Logger) *AuditPruneJob {
+	return &AuditPruneJob{
+		auditUseCase: auditUseCase,
+		interval:     auditConfig.GetPruneInterval(),
+		logger:       appLogger.Named("audit"),
+	}
+}
+
+// Start prunes expired audit events right away and then on every interval until the context is done.
+// Replicas may prune at the same time, they delete the same rows.
+func (j *AuditPruneJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.prune(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *AuditPruneJob) prune(ctx context.Context) {
+	deleted, err := j.auditUseCase.PruneEvents(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.ErrorContext(ctx, "audit prune failed", "deleted", deleted, "error", err)
+		}
+
+		return
+	}
+
+	if deleted > 0 {
+		j.logger.InfoContext(ctx, "expired audit events pruned", "deleted", deleted)
+	}
+}
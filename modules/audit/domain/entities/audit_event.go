@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/winartodev/apollo-be/modules/audit/usecase/dto"
+)
+
+type AuditEvent struct {
+	ID        int64
+	ActorType string
+	ActorID   *int64
+	UserID    *int64
+	Action    string
+	Result    string
+	IP        string
+	UserAgent string
+	RequestID string
+	Metadata  map[string]string
+	CreatedAt time.Time
+}
+
+func (e *AuditEvent) ToUseCaseData() dto.AuditEventDto {
+	return dto.AuditEventDto{
+		ID:        e.ID,
+		ActorType: e.ActorType,
+		ActorID:   e.ActorID,
+		UserID:    e.UserID,
+		Action:    e.Action,
+		Result:    e.Result,
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		RequestID: e.RequestID,
+		Metadata:  e.Metadata,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// AuditFilter selects audit events, zero values match every event
+type AuditFilter struct {
+	UserID  int64
+	ActorID int64
+	Action  string
+	Result  string
+	IP      string
+	From    time.Time
+	To      time.Time
+	Limit   int
+	Offset  int
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winartodev/apollo-be/modules/audit/domain/entities"
+)
+
+type AuditRepository interface {
+	InsertEventDB(ctx context.Context, event entities.AuditEvent) (err error)
+	GetEventsDB(ctx context.Context, filter entities.AuditFilter) (res []entities.AuditEvent, err error)
+	CountEventsDB(ctx context.Context, filter entities.AuditFilter) (total int64, err error)
+	// DeleteEventsBeforeDB deletes at most limit events created before the time
+	DeleteEventsBeforeDB(ctx context.Context, before time.Time, limit int) (deleted int64, err error)
+}
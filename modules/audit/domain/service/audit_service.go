@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/winartodev/apollo-be/helper"
+	"github.com/winartodev/apollo-be/modules/audit/domain/entities"
+	"github.com/winartodev/apollo-be/modules/audit/domain/repository"
+)
+
+const (
+	// maxUserAgentLength and maxRequestIDLength keep the values within the column sizes, VARCHAR counts characters
+	maxUserAgentLength = 512
+	maxRequestIDLength = 64
+)
+
+type AuditService interface {
+	RecordEvent(ctx context.Context, event entities.AuditEvent) (err error)
+	FindEvents(ctx context.Context, filter entities.AuditFilter) (res []entities.AuditEvent, total int64, err error)
+	// PruneEvents deletes the events created before the time, batchSize events per statement
+	PruneEvents(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error)
+}
+
+type auditService struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) (AuditService, error) {
+	return &auditService{
+		auditRepo: auditRepo,
+	}, nil
+}
+
+func (as *auditService) RecordEvent(ctx context.Context, event entities.AuditEvent) (err error) {
+	event.UserAgent = helper.Truncate(event.UserAgent, maxUserAgentLength)
+	event.RequestID = helper.Truncate(event.RequestID, maxRequestIDLength)
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]string)
+	}
+
+	return as.auditRepo.InsertEventDB(ctx, event)
+}
+
+func (as *auditService) FindEvents(ctx context.Context, filter entities.AuditFilter) (res []entities.AuditEvent, total int64, err error) {
+	total, err = as.auditRepo.CountEventsDB(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if total <= int64(filter.Offset) {
+		return []entities.AuditEvent{}, total, nil
+	}
+
+	res, err = as.auditRepo.GetEventsDB(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, total, nil
+}
+
+func (as *auditService) PruneEvents(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error) {
+	for {
+		count, err := as.auditRepo.DeleteEventsBeforeDB(ctx, before, batchSize)
+		deleted += count
+		if err != nil {
+			return deleted, err
+		}
+
+		if count < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
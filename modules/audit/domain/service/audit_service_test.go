@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/winartodev/apollo-be/modules/audit/domain/entities"
+	"github.com/winartodev/apollo-be/modules/audit/domain/repository"
+)
+
+// fakeAuditRepo keeps the last inserted event
+type fakeAuditRepo struct {
+	repository.AuditRepository
+	event entities.AuditEvent
+}
+
+func (f *fakeAuditRepo) InsertEventDB(ctx context.Context, event entities.AuditEvent) error {
+	f.event = event
+	return nil
+}
+
+func TestRecordEventTruncatesOnRuneBoundaries(t *testing.T) {
+	tests := []struct {
+		name          string
+		userAgent     string
+		requestID     string
+		wantUserAgent string
+		wantRequestID string
+	}{
+		{name: "short", userAgent: "Mozilla/5.0", requestID: "req-1", wantUserAgent: "Mozilla/5.0", wantRequestID: "req-1"},
+		{
+			name:          "ascii",
+			userAgent:     strings.Repeat("a", maxUserAgentLength+1),
+			requestID:     strings.Repeat("r", maxRequestIDLength+1),
+			wantUserAgent: strings.Repeat("a", maxUserAgentLength),
+			wantRequestID: strings.Repeat("r", maxRequestIDLength),
+		},
+		{
+			name:          "multi-byte",
+			userAgent:     "a" + strings.Repeat("日", maxUserAgentLength),
+			requestID:     strings.Repeat("é", maxRequestIDLength+1),
+			wantUserAgent: "a" + strings.Repeat("日", maxUserAgentLength-1),
+			wantRequestID: strings.Repeat("é", maxRequestIDLength),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAuditRepo{}
+			audit, err := NewAuditService(repo)
+			if err != nil {
+				t.Fatalf("NewAuditService() error = %v", err)
+			}
+
+			if err := audit.RecordEvent(context.Background(), entities.AuditEvent{UserAgent: tt.userAgent, RequestID: tt.requestID}); err != nil {
+				t.Fatalf("RecordEvent() error = %v", err)
+			}
+
+			if !utf8.ValidString(repo.event.UserAgent) || !utf8.ValidString(repo.event.RequestID) {
+				t.Fatalf("stored user agent %q or request ID %q is not valid UTF-8", repo.event.UserAgent, repo.event.RequestID)
+			}
+
+			if repo.event.UserAgent != tt.wantUserAgent {
+				t.Errorf("stored user agent has %d characters, want %d", utf8.RuneCountInString(repo.event.UserAgent), utf8.RuneCountInString(tt.wantUserAgent))
+			}
+
+			if repo.event.RequestID != tt.wantRequestID {
+				t.Errorf("stored request ID = %q, want %q", repo.event.RequestID, tt.wantRequestID)
+			}
+		})
+	}
+}
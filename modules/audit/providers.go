@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"github.com/google/wire"
+	"github.com/winartodev/apollo-be/infrastructure/provider"
+	"github.com/winartodev/apollo-be/modules/audit/delivery/http"
+	"github.com/winartodev/apollo-be/modules/audit/delivery/job"
+	auditService "github.com/winartodev/apollo-be/modules/audit/domain/service"
+	auditRepo "github.com/winartodev/apollo-be/modules/audit/repository"
+	auditUseCase "github.com/winartodev/apollo-be/modules/audit/usecase"
+)
+
+var repositorySet = wire.NewSet(
+	// Repository implementations
+	auditRepo.NewAuditRepository,
+)
+
+var serviceSet = wire.NewSet(
+	// Domain services
+	auditService.NewAuditService,
+)
+
+var useCaseSet = wire.NewSet(
+	// Use cases
+	auditUseCase.NewAuditUseCase,
+)
+
+var handlerSet = wire.NewSet(
+	// HTTP Handlers
+	http.NewAuditHandler,
+)
+
+var jobSet = wire.NewSet(
+	// Background jobs
+	job.NewAuditPruneJob,
+)
+
+var moduleSet = wire.NewSet(
+	provider.InfraProviderSet,
+	provider.MiddlewareProviderSet,
+	repositorySet,
+	serviceSet,
+	useCaseSet,
+	handlerSet,
+	jobSet,
+)
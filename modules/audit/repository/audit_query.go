@@ -0,0 +1,39 @@
+package repository
+
+const (
+	insertAuditEventQuery = `
+		INSERT INTO audit_events (actor_type, actor_id, user_id, action, result, ip, user_agent, request_id, metadata, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::jsonb, $10)
+	`
+
+	getAuditEventsQuery = `
+		SELECT
+		    id,
+		    actor_type,
+		    actor_id,
+		    user_id,
+		    action,
+		    result,
+		    ip,
+		    user_agent,
+		    request_id,
+		    metadata,
+		    created_at
+		FROM audit_events
+	`
+
+	countAuditEventsQuery = `
+		SELECT COUNT(*) FROM audit_events
+	`
+
+	// deleteAuditEventsBeforeQuery deletes in batches so pruning a large backlog doesn't hold long locks
+	deleteAuditEventsBeforeQuery = `
+		DELETE FROM audit_events
+		WHERE id IN (
+		    SELECT id FROM audit_events
+		    WHERE created_at < $1
+		    ORDER BY id
+		    LIMIT $2
+		)
+	`
+)
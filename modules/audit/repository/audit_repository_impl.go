@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/winartodev/apollo-be/infrastructure/database"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/audit/domain/entities"
+	"github.com/winartodev/apollo-be/modules/audit/domain/repository"
+)
+
+type AuditRepositoryImpl struct {
+	*database.Database
+}
+
+func NewAuditRepository(db *database.Database) (repository.AuditRepository, error) {
+	return &AuditRepositoryImpl{
+		Database: db,
+	}, nil
+}
+
+func (ar *AuditRepositoryImpl) InsertEventDB(ctx context.Context, event entities.AuditEvent) (err error) {
+	metadata, err := json.Marshal(event.Metadata)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domainError.ErrFailedRecordAuditEvent, err)
+	}
+
+	_, err = ar.Conn(ctx).ExecContext(
+		ctx,
+		insertAuditEventQuery,
+		event.ActorType,
+		event.ActorID,
+		event.UserID,
+		event.Action,
+		event.Result,
+		event.IP,
+		event.UserAgent,
+		event.RequestID,
+		string(metadata),
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domainError.ErrFailedRecordAuditEvent, err)
+	}
+
+	return nil
+}
+
+func (ar *AuditRepositoryImpl) GetEventsDB(ctx context.Context, filter entities.AuditFilter) (res []entities.AuditEvent, err error) {
+	where, args := buildAuditFilter(filter)
+	query := fmt.Sprintf("%s %s ORDER BY id DESC LIMIT $%d OFFSET $%d", getAuditEventsQuery, where, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := ar.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainError.ErrFailedGetAuditEvents, err)
+	}
+
+	defer rows.Close()
+
+	res = make([]entities.AuditEvent, 0)
+	for rows.Next() {
+		var event entities.AuditEvent
+		var actorID, userID sql.NullInt64
+		var metadata []byte
+
+		err = rows.Scan(
+			&event.ID,
+			&event.ActorType,
+			&actorID,
+			&userID,
+			&event.Action,
+			&event.Result,
+			&event.IP,
+			&event.UserAgent,
+			&event.RequestID,
+			&metadata,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domainError.ErrFailedGetAuditEvents, err)
+		}
+
+		if err = json.Unmarshal(metadata, &event.Metadata); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainError.ErrFailedGetAuditEvents, err)
+		}
+
+		event.ActorID = database.Int64Ptr(actorID)
+		event.UserID = database.Int64Ptr(userID)
+		res = append(res, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainError.ErrFailedGetAuditEvents, err)
+	}
+
+	return res, nil
+}
+
+func (ar *AuditRepositoryImpl) CountEventsDB(ctx context.Context, filter entities.AuditFilter) (total int64, err error) {
+	where, args := buildAuditFilter(filter)
+
+	err = ar.Conn(ctx).QueryRowContext(ctx, fmt.Sprintf("%s %s", countAuditEventsQuery, where), args...).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", domainError.ErrFailedGetAuditEvents, err)
+	}
+
+	return total, nil
+}
+
+func (ar *AuditRepositoryImpl) DeleteEventsBeforeDB(ctx context.Context, before time.Time, limit int) (deleted int64, err error) {
+	result, err := ar.Conn(ctx).ExecContext(ctx, deleteAuditEventsBeforeQuery, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune audit events: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// buildAuditFilter returns the WHERE clause of the filter and its arguments, numbered from $1
+func buildAuditFilter(filter entities.AuditFilter) (where string, args []interface{}) {
+	var conditions []string
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.UserID != 0 {
+		add("user_id = $%d", filter.UserID)
+	}
+
+	if filter.ActorID != 0 {
+		add("actor_id = $%d", filter.ActorID)
+	}
+
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+
+	if filter.Result != "" {
+		add("result = $%d", filter.Result)
+	}
+
+	if filter.IP != "" {
+		add("ip = $%d", filter.IP)
+	}
+
+	if !filter.From.IsZero() {
+		add("created_at >= $%d", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		add("created_at < $%d", filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/internal/domain"
+	"github.com/winartodev/apollo-be/modules/audit/domain/entities"
+	"github.com/winartodev/apollo-be/modules/audit/domain/service"
+	"github.com/winartodev/apollo-be/modules/audit/usecase/dto"
+)
+
+// AuditUseCase keeps the audit log of authentication and account events, Handle subscribes to the event bus
+type AuditUseCase interface {
+	Handle(ctx context.Context, event domain.AccountEvent) (err error)
+	GetCurrentUserActivity(ctx context.Context, page dto.PageDto) (res *dto.AuditEventListDto, err error)
+	GetEvents(ctx context.Context, filter dto.AuditFilterDto) (res *dto.AuditEventListDto, err error)
+	// PruneEvents deletes the events older than the configured retention
+	PruneEvents(ctx context.Context) (deleted int64, err error)
+}
+
+type auditUseCase struct {
+	auditService service.AuditService
+	config       *config.Audit
+	transactor   domain.Transactor
+	logger       *slog.Logger
+}
+
+func NewAuditUseCase(auditService service.AuditService, auditConfig *config.Audit, transactor domain.Transactor, appLogger *logger.Logger) AuditUseCase {
+	return &auditUseCase{
+		auditService: auditService,
+		config:       auditConfig,
+		transactor:   transactor,
+		logger:       appLogger.Named("audit"),
+	}
+}
+
+// Handle records the event in the publisher's transaction, so a rolled back change leaves no record.
+// The audit log fails open: a failed write is logged and never fails the change, it runs in a savepoint
+// so the failed insert doesn't abort the publisher's transaction. Handle always returns nil.
+func (au *auditUseCase) Handle(ctx context.Context, event domain.AccountEvent) (err error) {
+	auditEvent := entities.AuditEvent{
+		ActorType: string(event.Actor),
+		Action:    string(event.Type),
+		Result:    string(event.Result),
+		IP:        event.IP,
+		UserAgent: event.UserAgent,
+		RequestID: event.RequestID,
+		Metadata:  event.Metadata,
+		CreatedAt: event.OccurredAt,
+	}
+
+	if event.ActorID != 0 {
+		auditEvent.ActorID = &event.ActorID
+	}
+
+	if event.UserID != 0 {
+		auditEvent.UserID = &event.UserID
+	}
+
+	err = au.transactor.WithSavepoint(ctx, func(ctx context.Context) error {
+		return au.auditService.RecordEvent(ctx, auditEvent)
+	})
+	if err != nil {
+		au.logger.ErrorContext(ctx, "failed to record audit event", "action", event.Type, "result", event.Result, "user_id", event.UserID, "error", err)
+	}
+
+	return nil
+}
+
+func (au *auditUseCase) GetCurrentUserActivity(ctx context.Context, page dto.PageDto) (res *dto.AuditEventListDto, err error) {
+	userID, err := infraContext.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return au.GetEvents(ctx, dto.AuditFilterDto{UserID: userID, PageDto: page})
+}
+
+func (au *auditUseCase) GetEvents(ctx context.Context, filter dto.AuditFilterDto) (res *dto.AuditEventListDto, err error) {
+	events, total, err := au.auditService.FindEvents(ctx, entities.AuditFilter{
+		UserID:  filter.UserID,
+		ActorID: filter.ActorID,
+		Action:  filter.Action,
+		Result:  filter.Result,
+		IP:      filter.IP,
+		From:    filter.From,
+		To:      filter.To,
+		Limit:   filter.PerPage,
+		Offset:  filter.Offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = &dto.AuditEventListDto{
+		Events:  make([]dto.AuditEventDto, len(events)),
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}
+
+	for i := range events {
+		res.Events[i] = events[i].ToUseCaseData()
+	}
+
+	return res, nil
+}
+
+func (au *auditUseCase) PruneEvents(ctx context.Context) (deleted int64, err error) {
+	before := time.Now().Add(-au.config.GetRetention())
+
+	return au.auditService.PruneEvents(ctx, before, au.config.GetPruneBatchSize())
+}
@@ -0,0 +1,135 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/winartodev/apollo-be/infrastructure/http/response"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/audit/delivery/http/dto"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+type AuditEventDto struct {
+	ID        int64
+	ActorType string
+	ActorID   *int64
+	UserID    *int64
+	Action    string
+	Result    string
+	IP        string
+	UserAgent string
+	RequestID string
+	Metadata  map[string]string
+	CreatedAt time.Time
+}
+
+func (e *AuditEventDto) ToResponse() dto.AuditEventResponse {
+	return dto.AuditEventResponse{
+		ID:        e.ID,
+		ActorType: e.ActorType,
+		ActorID:   e.ActorID,
+		UserID:    e.UserID,
+		Action:    e.Action,
+		Result:    e.Result,
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		RequestID: e.RequestID,
+		Metadata:  e.Metadata,
+		CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// AuditEventListDto is one page of audit events, newest first
+type AuditEventListDto struct {
+	Events  []AuditEventDto
+	Total   int64
+	Page    int
+	PerPage int
+}
+
+func (l *AuditEventListDto) ToResponse() response.PaginateResponse {
+	events := make([]dto.AuditEventResponse, len(l.Events))
+	for i := range l.Events {
+		events[i] = l.Events[i].ToResponse()
+	}
+
+	return response.PaginateResponse{
+		Data:       events,
+		Total:      l.Total,
+		Page:       l.Page,
+		PerPage:    l.PerPage,
+		TotalPages: int((l.Total + int64(l.PerPage) - 1) / int64(l.PerPage)),
+	}
+}
+
+// PageDto selects a page, the first page of 20 events by default
+type PageDto struct {
+	Page    int
+	PerPage int
+}
+
+func NewPageDto(page int, perPage int) PageDto {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+
+	return PageDto{
+		Page:    page,
+		PerPage: min(perPage, maxPerPage),
+	}
+}
+
+// Offset returns the number of events before the page
+func (p PageDto) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+type AuditFilterDto struct {
+	UserID  int64
+	ActorID int64
+	Action  string
+	Result  string
+	IP      string
+	From    time.Time
+	To      time.Time
+	PageDto
+}
+
+// NewAuditFilterDto parses the RFC 3339 time range of the query, From is inclusive and To exclusive
+func NewAuditFilterDto(req dto.AuditEventQueryRequest) (res AuditFilterDto, err error) {
+	res = AuditFilterDto{
+		UserID:  req.UserID,
+		ActorID: req.ActorID,
+		Action:  req.Action,
+		Result:  req.Result,
+		IP:      req.IP,
+		PageDto: NewPageDto(req.Page, req.PerPage),
+	}
+
+	if req.From != "" {
+		if res.From, err = time.Parse(time.RFC3339, req.From); err != nil {
+			return res, fmt.Errorf("%w: from must be an RFC 3339 time", domainError.ErrInvalidAuditFilter)
+		}
+	}
+
+	if req.To != "" {
+		if res.To, err = time.Parse(time.RFC3339, req.To); err != nil {
+			return res, fmt.Errorf("%w: to must be an RFC 3339 time", domainError.ErrInvalidAuditFilter)
+		}
+	}
+
+	if !res.From.IsZero() && !res.To.IsZero() && !res.From.Before(res.To) {
+		return res, fmt.Errorf("%w: from must be before to", domainError.ErrInvalidAuditFilter)
+	}
+
+	return res, nil
+}
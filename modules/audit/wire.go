@@ -0,0 +1,45 @@
+//go:build wireinject
+// +build wireinject
+
+package audit
+
+import (
+	"database/sql"
+
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/modules/audit/delivery/http"
+	"github.com/winartodev/apollo-be/modules/audit/delivery/job"
+	"github.com/winartodev/apollo-be/modules/audit/usecase"
+)
+
+func InitializeAuditAPI(
+	db *sql.DB,
+	redis *redis.Client,
+	jwtConfig *config.Jwt,
+	auditConfig *config.Audit,
+	appLogger *logger.Logger,
+) (*http.AuditHandler, error) {
+	wire.Build(moduleSet)
+	return &http.AuditHandler{}, nil
+}
+
+func InitializeAuditUseCase(
+	db *sql.DB,
+	auditConfig *config.Audit,
+	appLogger *logger.Logger,
+) (usecase.AuditUseCase, error) {
+	wire.Build(moduleSet)
+	return nil, nil
+}
+
+func InitializeAuditPruneJob(
+	db *sql.DB,
+	auditConfig *config.Audit,
+	appLogger *logger.Logger,
+) (*job.AuditPruneJob, error) {
+	wire.Build(moduleSet)
+	return &job.AuditPruneJob{}, nil
+}
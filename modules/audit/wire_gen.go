@@ -0,0 +1,90 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package audit
+
+import (
+	"database/sql"
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/auth"
+	"github.com/winartodev/apollo-be/infrastructure/database"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/infrastructure/middleware"
+	redis2 "github.com/winartodev/apollo-be/infrastructure/redis"
+	"github.com/winartodev/apollo-be/modules/audit/delivery/http"
+	"github.com/winartodev/apollo-be/modules/audit/delivery/job"
+	"github.com/winartodev/apollo-be/modules/audit/domain/service"
+	"github.com/winartodev/apollo-be/modules/audit/repository"
+	"github.com/winartodev/apollo-be/modules/audit/usecase"
+)
+
+// Injectors from wire.go:
+
+func InitializeAuditAPI(db *sql.DB, redis3 *redis.Client, jwtConfig *config.Jwt, auditConfig *config.Audit, appLogger *logger.Logger) (*http.AuditHandler, error) {
+	databaseDatabase, err := database.NewDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	auditRepository, err := repository.NewAuditRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	auditService, err := service.NewAuditService(auditRepository)
+	if err != nil {
+		return nil, err
+	}
+	auditUseCase := usecase.NewAuditUseCase(auditService, auditConfig, databaseDatabase, appLogger)
+	jwt, err := auth.NewJWT(jwtConfig)
+	if err != nil {
+		return nil, err
+	}
+	tokenService := auth.NewJwtTokenService(jwt)
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	userStatusService := auth.NewUserStatusService(databaseDatabase, redisRedis)
+	apiKeyService := auth.NewAPIKeyService(databaseDatabase, redisRedis)
+	middlewareMiddleware := middleware.NewMiddleware(tokenService, userStatusService, apiKeyService)
+	auditHandler := http.NewAuditHandler(auditUseCase, middlewareMiddleware)
+	return auditHandler, nil
+}
+
+func InitializeAuditUseCase(db *sql.DB, auditConfig *config.Audit, appLogger *logger.Logger) (usecase.AuditUseCase, error) {
+	databaseDatabase, err := database.NewDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	auditRepository, err := repository.NewAuditRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	auditService, err := service.NewAuditService(auditRepository)
+	if err != nil {
+		return nil, err
+	}
+	auditUseCase := usecase.NewAuditUseCase(auditService, auditConfig, databaseDatabase, appLogger)
+	return auditUseCase, nil
+}
+
+func InitializeAuditPruneJob(db *sql.DB, auditConfig *config.Audit, appLogger *logger.Logger) (*job.AuditPruneJob, error) {
+	databaseDatabase, err := database.NewDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	auditRepository, err := repository.NewAuditRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	auditService, err := service.NewAuditService(auditRepository)
+	if err != nil {
+		return nil, err
+	}
+	auditUseCase := usecase.NewAuditUseCase(auditService, auditConfig, databaseDatabase, appLogger)
+	auditPruneJob := job.NewAuditPruneJob(auditUseCase, auditConfig, appLogger)
+	return auditPruneJob, nil
+}
@@ -3,9 +3,10 @@ package enums
 type AuthOperation string
 
 const (
-	AuthSignUp        AuthOperation = "SignUp"
-	AuthSignIn        AuthOperation = "SignIn"
-	AuthSignOut       AuthOperation = "SignOut"
-	AuthResetPassword AuthOperation = "ResetPassword"
-	AuthRequestReset  AuthOperation = "RequestReset"
+	AuthSignUp         AuthOperation = "SignUp"
+	AuthSignIn         AuthOperation = "SignIn"
+	AuthSignOut        AuthOperation = "SignOut"
+	AuthResetPassword  AuthOperation = "ResetPassword"
+	AuthRequestReset   AuthOperation = "RequestReset"
+	AuthReportActivity AuthOperation = "ReportActivity"
 )
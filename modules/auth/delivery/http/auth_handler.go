@@ -81,6 +81,7 @@ func (ah *AuthHandler) SignUp(c echo.Context) error {
 //	@Success		200		{object}	response.Response{data=dto.AuthResponse}	"User authenticated successfully"
 //	@Failure		400		{object}	response.ErrorResponse						"Invalid request payload"
 //	@Failure		401		{object}	response.ErrorResponse						"Invalid username or password"
+//	@Failure		403		{object}	response.ErrorResponse						"User is inactive"
 //	@Failure		422		{object}	response.ErrorResponse						"Validation error"
 //	@Failure		500		{object}	response.ErrorResponse						"Internal server error"
 //	@Router			/auth/sign-in [post]
@@ -278,6 +279,47 @@ func (ah *AuthHandler) ResetPassword(c echo.Context) error {
 	return response.SuccessResponse(c, http.StatusOK, "ok", resp, nil)
 }
 
+// ReportActivity godoc
+//
+//	@Summary		Report unrecognized account activity
+//	@Description	Revoke every access and refresh token of the user and send an OTP for password reset, using the token of a security notification email
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		dto.ReportActivityRequest							true	"Report Activity Request Payload"
+//	@Success		200		{object}	response.Response{data=dto.RequestResetResponse}	"Response containing OTP info"
+//	@Failure		400		{object}	response.ErrorResponse								"Invalid request payload or token"
+//	@Failure		422		{object}	response.ErrorResponse								"Validation error"
+//	@Failure		500		{object}	response.ErrorResponse								"Internal server error"
+//	@Router			/auth/report-activity [post]
+func (ah *AuthHandler) ReportActivity(c echo.Context) error {
+	var req dto.ReportActivityRequest
+	if err := c.Bind(&req); err != nil {
+		return response.FailedResponse(c, http.StatusBadRequest, fmt.Errorf(response.ErrInvalidRequestPayload, err))
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.ValidationErrResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	res, err := ah.authUseCase.ReportActivity(ctx, req.Token)
+	if err != nil {
+		return response.FailedResponse(c, http.StatusInternalServerError, err)
+	}
+
+	resp := dto.RequestResetResponse{
+		RedirectionLink: ah.buildRedirectionLink(ctx, enums.AuthReportActivity),
+		Otp: &dto.OtpResponse{
+			RetryAttemptsLeft: res.Otp.RetryAttemptsLeft,
+			ExpiresIn:         res.Otp.ExpiresIn,
+			RetryAfterIn:      res.Otp.RetryAfterIn,
+		},
+	}
+
+	return response.SuccessResponse(c, http.StatusOK, "ok", resp, nil)
+}
+
 func (ah *AuthHandler) RegisterRoutes(api *echo.Group) error {
 	auth := api.Group("/auth")
 	auth.POST("/sign-up", ah.SignUp)
@@ -287,6 +329,7 @@ func (ah *AuthHandler) RegisterRoutes(api *echo.Group) error {
 	auth.POST("/refresh", ah.RefreshToken, ah.middleware.HandleRefreshToken())
 	auth.POST("/request-reset", ah.RequestReset)
 	auth.POST("/reset-password", ah.ResetPassword)
+	auth.POST("/report-activity", ah.ReportActivity)
 
 	return nil
 }
@@ -315,7 +358,7 @@ func (ah *AuthHandler) getMobileRedirection(operation enums.AuthOperation) (res
 		return "/signInPage"
 	case enums.AuthResetPassword:
 		return "/signInPage"
-	case enums.AuthRequestReset:
+	case enums.AuthRequestReset, enums.AuthReportActivity:
 		return "/otpVerificationPage"
 	default:
 		return ""
@@ -332,7 +375,7 @@ func (ah *AuthHandler) getWebRedirection(operation enums.AuthOperation) (res str
 		return "/sign-in"
 	case enums.AuthResetPassword:
 		return "/sign-in"
-	case enums.AuthRequestReset:
+	case enums.AuthRequestReset, enums.AuthReportActivity:
 		return "/verification"
 	default:
 		return ""
@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/winartodev/apollo-be/modules/auth/usecase/dto"
+
+// CreateInvitationRequest represents the request to create an invitation
+// swagger:model CreateInvitationRequest
+type CreateInvitationRequest struct {
+	// Email to send the invitation to, the invitation can only be used by this email when set
+	// format: email
+	// example: friend@example.com
+	Email string `json:"email" validate:"omitempty,email"`
+
+	// Number of sign-ups allowed with this invitation, uses the configured default when empty
+	// example: 1
+	MaxUses int `json:"max_uses" validate:"omitempty,min=1,max=100"`
+
+	// Lifetime of the invitation in seconds, uses the configured default when empty
+	// example: 604800
+	ExpiresIn int64 `json:"expires_in" validate:"omitempty,min=60"`
+}
+
+func (r CreateInvitationRequest) ToUseCaseData() dto.CreateInvitationDto {
+	return dto.CreateInvitationDto{
+		Email:     r.Email,
+		MaxUses:   r.MaxUses,
+		ExpiresIn: r.ExpiresIn,
+	}
+}
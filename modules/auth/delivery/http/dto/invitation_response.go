@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/winartodev/apollo-be/modules/auth/usecase/dto"
+)
+
+// InvitationResponse represents an invitation
+// swagger:model InvitationResponse
+type InvitationResponse struct {
+	// Invitation code
+	// example: MFRGGZDFMZTWQ2LK
+	Code string `json:"code"`
+
+	// Email the invitation is restricted to
+	// example: friend@example.com
+	Email string `json:"email,omitempty"`
+
+	// Sign-up link containing the invitation code
+	// example: https://app.example.com/sign-up?invite=MFRGGZDFMZTWQ2LK
+	Link string `json:"link,omitempty"`
+
+	// Number of sign-ups allowed
+	// example: 1
+	MaxUses int `json:"max_uses"`
+
+	// Number of sign-ups already done with this invitation
+	// example: 0
+	UsedCount int `json:"used_count"`
+
+	// Expiration time
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Creation time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewInvitationResponse(data dto.InvitationDto) InvitationResponse {
+	return InvitationResponse{
+		Code:      data.Code,
+		Email:     data.Email,
+		Link:      data.Link,
+		MaxUses:   data.MaxUses,
+		UsedCount: data.UsedCount,
+		ExpiresAt: data.ExpiresAt,
+		CreatedAt: data.CreatedAt,
+	}
+}
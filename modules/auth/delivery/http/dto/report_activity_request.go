@@ -0,0 +1,8 @@
+package dto
+
+// ReportActivityRequest represents the request for reporting unrecognized account activity
+// swagger:model ReportActivityRequest
+type ReportActivityRequest struct {
+	// Token from the "this wasn't me" link of a security notification email
+	Token string `json:"token" validate:"required"`
+}
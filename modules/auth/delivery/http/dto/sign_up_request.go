@@ -26,10 +26,17 @@ type SignUpRequest struct {
 	// example: john.doe@example.com
 	Email string `json:"email"`
 
-	// Phone number (optional)
-	// pattern: ^\+?[1-9]\d{1,14}$
-	// example: +1234567890
-	PhoneNumber string `json:"phone_number"`
+	// Phone number (optional), numbers without a country calling code are parsed using country_code
+	// example: 0812-3456-7890
+	PhoneNumber string `json:"phone_number" validate:"omitempty,phone=CountryCode"`
+
+	// Country of the phone number as ISO 3166-1 alpha-2, alpha-3 or numeric code (optional)
+	// example: ID
+	CountryCode string `json:"country_code" validate:"omitempty,min=2,max=3"`
+
+	// Invitation code, required when registration is invite only
+	// example: MFRGGZDFMZTWQ2LK
+	InviteCode string `json:"invite_code"`
 }
 
 func (r SignUpRequest) ToUseCaseData() dto.SignUpDto {
@@ -38,5 +45,7 @@ func (r SignUpRequest) ToUseCaseData() dto.SignUpDto {
 		Password:    r.Password,
 		Email:       r.Email,
 		PhoneNumber: r.PhoneNumber,
+		CountryCode: r.CountryCode,
+		InviteCode:  r.InviteCode,
 	}
 }
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/winartodev/apollo-be/infrastructure/http/response"
+	"github.com/winartodev/apollo-be/infrastructure/middleware"
+	"github.com/winartodev/apollo-be/modules/auth/delivery/http/dto"
+	"github.com/winartodev/apollo-be/modules/auth/usecase"
+)
+
+type InvitationHandler struct {
+	middleware        *middleware.Middleware
+	invitationUseCase usecase.InvitationUseCase
+}
+
+func NewInvitationHandler(invitationUseCase usecase.InvitationUseCase, middleware *middleware.Middleware) *InvitationHandler {
+	return &InvitationHandler{
+		middleware:        middleware,
+		invitationUseCase: invitationUseCase,
+	}
+}
+
+// CreateInvitation godoc
+//
+//	@Summary		Create invitation
+//	@Description	Create an invitation code, an invitation link is emailed when an email is provided
+//	@Tags			Invitation
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		dto.CreateInvitationRequest						true	"Invitation data"
+//	@Success		201		{object}	response.Response{data=dto.InvitationResponse}	"Invitation created successfully"
+//	@Failure		400		{object}	response.ErrorResponse							"Invalid request payload"
+//	@Failure		401		{object}	response.ErrorResponse							"Unauthorized"
+//	@Failure		403		{object}	response.ErrorResponse							"Invite quota exceeded or invitations disabled"
+//	@Failure		409		{object}	response.ErrorResponse							"Email already registered"
+//	@Failure		422		{object}	response.ErrorResponse							"Validation error"
+//	@Failure		500		{object}	response.ErrorResponse							"Internal server error"
+//	@Router			/invitations [post]
+func (ih *InvitationHandler) CreateInvitation(c echo.Context) error {
+	var req dto.CreateInvitationRequest
+
+	if err := c.Bind(&req); err != nil {
+		return response.FailedResponse(c, http.StatusBadRequest, fmt.Errorf(response.ErrInvalidRequestPayload, err))
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.ValidationErrResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	res, err := ih.invitationUseCase.CreateInvitation(ctx, req.ToUseCaseData())
+	if err != nil {
+		return response.FailedResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return response.SuccessResponse(c, http.StatusCreated, "Invitation created successfully", dto.NewInvitationResponse(*res), nil)
+}
+
+// GetInvitations godoc
+//
+//	@Summary		List invitations
+//	@Description	List the invitations created by the current user
+//	@Tags			Invitation
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response{data=[]dto.InvitationResponse}	"Invitations"
+//	@Failure		401	{object}	response.ErrorResponse								"Unauthorized"
+//	@Failure		500	{object}	response.ErrorResponse								"Internal server error"
+//	@Router			/invitations [get]
+func (ih *InvitationHandler) GetInvitations(c echo.Context) error {
+	ctx := c.Request().Context()
+	res, err := ih.invitationUseCase.GetInvitations(ctx)
+	if err != nil {
+		return response.FailedResponse(c, http.StatusInternalServerError, err)
+	}
+
+	resp := make([]dto.InvitationResponse, len(res))
+	for i, invitation := range res {
+		resp[i] = dto.NewInvitationResponse(invitation)
+	}
+
+	return response.SuccessResponse(c, http.StatusOK, "OK", resp, nil)
+}
+
+func (ih *InvitationHandler) RegisterRoutes(api *echo.Group) error {
+	invitation := api.Group("/invitations", ih.middleware.HandleWithAuth())
+	invitation.POST("", ih.CreateInvitation)
+	invitation.GET("", ih.GetInvitations)
+
+	return nil
+}
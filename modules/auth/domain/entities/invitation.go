@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/winartodev/apollo-be/modules/auth/usecase/dto"
+)
+
+type Invitation struct {
+	ID        int64
+	Code      string
+	InviterID *int64
+	Email     string
+	MaxUses   int
+	UsedCount int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+func (i *Invitation) ToUseCaseData() dto.InvitationDto {
+	return dto.InvitationDto{
+		Code:      i.Code,
+		Email:     i.Email,
+		MaxUses:   i.MaxUses,
+		UsedCount: i.UsedCount,
+		ExpiresAt: i.ExpiresAt,
+		CreatedAt: i.CreatedAt,
+	}
+}
@@ -0,0 +1,13 @@
+package entities
+
+// SignInHistory tells whether a sign-in came from an IP and user agent the user signed in from before
+type SignInHistory struct {
+	IsFirstSignIn  bool
+	KnownIP        bool
+	KnownUserAgent bool
+}
+
+// IsNewDevice reports whether the sign-in should be notified, the first sign-in never is
+func (h *SignInHistory) IsNewDevice() bool {
+	return !h.IsFirstSignIn && (!h.KnownIP || !h.KnownUserAgent)
+}
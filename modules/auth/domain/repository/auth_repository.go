@@ -10,5 +10,9 @@ type AuthRepository interface {
 	RegisterNewUserDB(ctx context.Context, data entities.SharedUser) (id *int64, err error)
 	UpdateRefreshTokenDB(ctx context.Context, id int64, token *string) (err error)
 	GetUserDataDB(ctx context.Context, username string) (data *entities.SharedUser, err error)
+	UpdateSignInDB(ctx context.Context, id int64, token *string) (err error)
+	GetExistingUsernamesDB(ctx context.Context, usernames []string) (res []string, err error)
 	UpdatePasswordDB(ctx context.Context, id int64, hashedPassword string) (err error)
+	DeactivateUserDB(ctx context.Context, id int64) (err error)
+	RevokeTokensDB(ctx context.Context, id int64) (err error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/winartodev/apollo-be/modules/auth/domain/entities"
+)
+
+type InvitationRepository interface {
+	CreateInvitationDB(ctx context.Context, data entities.Invitation) (id *int64, err error)
+	GetInvitationsByInviterDB(ctx context.Context, inviterID int64) (res []entities.Invitation, err error)
+	ConsumeInvitationDB(ctx context.Context, code string, email string) (res *entities.Invitation, err error)
+	UseInviteQuotaDB(ctx context.Context, userID int64) (ok bool, err error)
+	AttachInvitationDB(ctx context.Context, userID int64, invitation *entities.Invitation, inviteQuota int) (err error)
+}
@@ -12,4 +12,8 @@ type OtpRepository interface {
 	SetOtpRedis(ctx context.Context, username string, data entities.OTP, exp time.Duration) (err error)
 	IncrOtpAttemptRedis(ctx context.Context, username string) (res *int64, err error)
 	GetOtpAttemptRedis(ctx context.Context, username string) (res *int64, err error)
+	// GetOtpAttemptTTLRedis returns how long the attempts are counted, zero when there are none
+	GetOtpAttemptTTLRedis(ctx context.Context, username string) (ttl time.Duration, err error)
+	// SetOtpLockedRedis marks the user's OTPs as locked and reports whether they weren't already
+	SetOtpLockedRedis(ctx context.Context, username string, exp time.Duration) (isNew bool, err error)
 }
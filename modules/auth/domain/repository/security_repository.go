@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/winartodev/apollo-be/modules/auth/domain/entities"
+)
+
+type SecurityRepository interface {
+	// RecordSignInDB stores the sign-in and returns the history before it
+	RecordSignInDB(ctx context.Context, userID int64, ip string, userAgent string) (res *entities.SignInHistory, err error)
+	SetReportTokenRedis(ctx context.Context, tokenHash string, userID int64, exp time.Duration) (err error)
+	// ConsumeReportTokenRedis deletes the token and returns its user, nil when it doesn't exist
+	ConsumeReportTokenRedis(ctx context.Context, tokenHash string) (userID *int64, err error)
+}
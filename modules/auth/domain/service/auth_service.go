@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/winartodev/apollo-be/infrastructure/tracing"
 	"github.com/winartodev/apollo-be/internal/domain"
 	"github.com/winartodev/apollo-be/internal/domain/entities"
 	domainError "github.com/winartodev/apollo-be/internal/domain/error"
@@ -11,9 +12,16 @@ import (
 
 type AuthService interface {
 	CreateNewUser(ctx context.Context, data entities.SharedUser) (res *entities.SharedUser, err error)
+	// VerifyUsernameAndPassword also returns the user with ErrInvalidUsernameOrPassword and ErrUserInactive,
+	// so the failed attempt can be recorded for the account
 	VerifyUsernameAndPassword(ctx context.Context, username string, password string) (res *entities.SharedUser, err error)
 	UpdateRefreshToken(ctx context.Context, id int64, token *string) (err error)
+	RecordSignIn(ctx context.Context, id int64, token *string) (err error)
 	UpdatePassword(ctx context.Context, id int64, password string) (err error)
+	GetUserByIdentifier(ctx context.Context, identifier string) (res *entities.SharedUser, err error)
+	DeactivateUser(ctx context.Context, id int64) (err error)
+	// RevokeTokens drops the refresh token and bumps the token version, so every token issued to the user is rejected
+	RevokeTokens(ctx context.Context, id int64) (err error)
 }
 
 type authService struct {
@@ -29,7 +37,7 @@ func NewAuthService(authRepo repository.AuthRepository, passwordService domain.P
 }
 
 func (as *authService) CreateNewUser(ctx context.Context, data entities.SharedUser) (res *entities.SharedUser, err error) {
-	encryptedPassword, err := as.passwordService.HashPassword(data.Password)
+	encryptedPassword, err := as.hashPassword(ctx, data.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +64,12 @@ func (as *authService) VerifyUsernameAndPassword(ctx context.Context, username s
 		return nil, domainError.ErrUserNotFound
 	}
 
-	if !as.passwordService.ComparePassword(password, user.Password) {
-		return nil, domainError.ErrInvalidUsernameOrPassword
+	if !as.comparePassword(ctx, password, user.Password) {
+		return user, domainError.ErrInvalidUsernameOrPassword
+	}
+
+	if !user.CanAuthenticate() {
+		return user, domainError.ErrUserInactive
 	}
 
 	return user, nil
@@ -67,11 +79,55 @@ func (as *authService) UpdateRefreshToken(ctx context.Context, id int64, token *
 	return as.authRepo.UpdateRefreshTokenDB(ctx, id, token)
 }
 
+// RecordSignIn stores the new refresh token and last login time in a single
+// update that only succeeds while the user is still active.
+func (as *authService) RecordSignIn(ctx context.Context, id int64, token *string) (err error) {
+	return as.authRepo.UpdateSignInDB(ctx, id, token)
+}
+
 func (as *authService) UpdatePassword(ctx context.Context, id int64, password string) (err error) {
-	encryptedPassword, err := as.passwordService.HashPassword(password)
+	encryptedPassword, err := as.hashPassword(ctx, password)
 	if err != nil {
 		return err
 	}
 
 	return as.authRepo.UpdatePasswordDB(ctx, id, encryptedPassword)
 }
+
+// GetUserByIdentifier returns the user with the given username or email
+func (as *authService) GetUserByIdentifier(ctx context.Context, identifier string) (res *entities.SharedUser, err error) {
+	user, err := as.authRepo.GetUserDataDB(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domainError.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// DeactivateUser blocks the user from signing in and drops the refresh token
+func (as *authService) DeactivateUser(ctx context.Context, id int64) (err error) {
+	return as.authRepo.DeactivateUserDB(ctx, id)
+}
+
+func (as *authService) RevokeTokens(ctx context.Context, id int64) (err error) {
+	return as.authRepo.RevokeTokensDB(ctx, id)
+}
+
+// hashPassword and comparePassword get their own spans, bcrypt is deliberately slow
+func (as *authService) hashPassword(ctx context.Context, password string) (hash string, err error) {
+	_, span := tracer.Start(ctx, "PasswordService.HashPassword")
+	defer func() { tracing.End(span, err) }()
+
+	return as.passwordService.HashPassword(password)
+}
+
+func (as *authService) comparePassword(ctx context.Context, password string, hash string) bool {
+	_, span := tracer.Start(ctx, "PasswordService.ComparePassword")
+	defer span.End()
+
+	return as.passwordService.ComparePassword(password, hash)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/internal/domain"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/auth/domain/entities"
+	"github.com/winartodev/apollo-be/modules/auth/domain/repository"
+)
+
+const (
+	invitationCodeBytes = 10
+)
+
+var invitationCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type InvitationService interface {
+	CreateInvitation(ctx context.Context, inviterID int64, email string, maxUses int, expiresIn time.Duration) (res *entities.Invitation, err error)
+	GetInvitations(ctx context.Context, inviterID int64) (res []entities.Invitation, err error)
+	AuthorizeRegistration(ctx context.Context, email string, code string) (res *entities.Invitation, err error)
+	AttachInvitation(ctx context.Context, userID int64, invitation *entities.Invitation) (err error)
+}
+
+type invitationService struct {
+	invitationRepo repository.InvitationRepository
+	config         *config.Watcher
+	transactor     domain.Transactor
+}
+
+func NewInvitationService(invitationRepo repository.InvitationRepository, configWatcher *config.Watcher, transactor domain.Transactor) (InvitationService, error) {
+	return &invitationService{
+		invitationRepo: invitationRepo,
+		config:         configWatcher,
+		transactor:     transactor,
+	}, nil
+}
+
+// registration returns the registration settings of the current configuration snapshot
+func (is *invitationService) registration() *config.Registration {
+	return &is.config.Current().Registration
+}
+
+func (is *invitationService) CreateInvitation(ctx context.Context, inviterID int64, email string, maxUses int, expiresIn time.Duration) (res *entities.Invitation, err error) {
+	if maxUses <= 0 {
+		maxUses = is.registration().GetInviteMaxUses()
+	}
+
+	if expiresIn <= 0 {
+		expiresIn = is.registration().GetInviteExpiration()
+	}
+
+	code, err := is.generateCode()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitation := entities.Invitation{
+		Code:      code,
+		InviterID: &inviterID,
+		Email:     email,
+		MaxUses:   maxUses,
+		ExpiresAt: now.Add(expiresIn),
+		CreatedAt: now,
+	}
+
+	// The quota is only spent when the invitation is stored, joins the caller's transaction if there is one
+	err = is.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := is.invitationRepo.UseInviteQuotaDB(ctx, inviterID)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return domainError.ErrInviteQuotaExceeded
+		}
+
+		id, err := is.invitationRepo.CreateInvitationDB(ctx, invitation)
+		if err != nil {
+			return err
+		}
+
+		invitation.ID = *id
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (is *invitationService) GetInvitations(ctx context.Context, inviterID int64) (res []entities.Invitation, err error) {
+	return is.invitationRepo.GetInvitationsByInviterDB(ctx, inviterID)
+}
+
+// AuthorizeRegistration applies the configured registration mode. A provided invitation code is
+// always consumed so the inviter is tracked, even when the mode doesn't require it. The caller runs it,
+// the user creation and AttachInvitation in one transaction so a failed sign-up releases the invitation.
+func (is *invitationService) AuthorizeRegistration(ctx context.Context, email string, code string) (res *entities.Invitation, err error) {
+	if code != "" {
+		invitation, err := is.invitationRepo.ConsumeInvitationDB(ctx, code, email)
+		if err != nil {
+			return nil, err
+		}
+
+		if invitation == nil {
+			return nil, domainError.ErrInvalidInvitation
+		}
+
+		return invitation, nil
+	}
+
+	switch is.registration().GetMode() {
+	case config.RegistrationModeOpen:
+		return nil, nil
+	case config.RegistrationModeInviteOnly:
+		return nil, domainError.ErrInvitationRequired
+	case config.RegistrationModeDomainAllowlist:
+		if !is.registration().IsDomainAllowed(email) {
+			return nil, domainError.ErrEmailDomainNotAllowed
+		}
+
+		return nil, nil
+	default:
+		return nil, domainError.ErrRegistrationClosed
+	}
+}
+
+// AttachInvitation records who invited the new user and grants the default invite quota. It must run in
+// the transaction that consumed the invitation and created the user, see AuthorizeRegistration.
+func (is *invitationService) AttachInvitation(ctx context.Context, userID int64, invitation *entities.Invitation) (err error) {
+	return is.invitationRepo.AttachInvitationDB(ctx, userID, invitation, is.registration().DefaultInviteQuota)
+}
+
+func (is *invitationService) generateCode() (res string, err error) {
+	buf := make([]byte, invitationCodeBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate invitation code: %v", err)
+	}
+
+	return invitationCodeEncoding.EncodeToString(buf), nil
+}
@@ -7,28 +7,29 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/winartodev/apollo-be/config"
 	domainError "github.com/winartodev/apollo-be/internal/domain/error"
 	"github.com/winartodev/apollo-be/modules/auth/domain/entities"
 	"github.com/winartodev/apollo-be/modules/auth/domain/repository"
 )
 
-const (
-	otpExp         = 3 * time.Minute
-	otpMaxAttempts = 3
-)
-
 type OtpService interface {
 	GetOTP(ctx context.Context, username string) (otp *string, retryLeft *int64, err error)
 	ValidateOTP(ctx context.Context, username string, otp *string) (valid bool, err error)
+	// LockOTP is called when GetOTP returned ErrOtpTooManyRequest, it returns when new OTPs can be requested
+	// and whether this is the first call since the lock started, so the lock is only notified once
+	LockOTP(ctx context.Context, username string) (lockedUntil time.Time, isNew bool, err error)
 }
 
 type otpService struct {
 	otpRepo repository.OtpRepository
+	config  *config.Watcher
 }
 
-func NewOtpService(otpRepo repository.OtpRepository) (OtpService, error) {
+func NewOtpService(otpRepo repository.OtpRepository, configWatcher *config.Watcher) (OtpService, error) {
 	return &otpService{
 		otpRepo: otpRepo,
+		config:  configWatcher,
 	}, nil
 }
 
@@ -43,13 +44,14 @@ func (os *otpService) GetOTP(ctx context.Context, username string) (otp *string,
 		return nil, nil, err
 	}
 
-	if currentAttempt != nil && *currentAttempt >= otpMaxAttempts {
+	otpConfig := os.config.Current().OTP
+	if currentAttempt != nil && *currentAttempt >= otpConfig.GetMaxAttempts() {
 		return nil, nil, domainError.ErrOtpTooManyRequest
 	}
 
 	err = os.otpRepo.SetOtpRedis(ctx, username, entities.OTP{
 		Number: *otp,
-	}, otpExp)
+	}, otpConfig.GetExpiration())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -79,6 +81,25 @@ func (os *otpService) ValidateOTP(ctx context.Context, username string, otp *str
 	return true, err
 }
 
+func (os *otpService) LockOTP(ctx context.Context, username string) (lockedUntil time.Time, isNew bool, err error) {
+	ttl, err := os.otpRepo.GetOtpAttemptTTLRedis(ctx, username)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	lockedUntil = time.Now().Add(ttl)
+	if ttl == 0 {
+		return lockedUntil, false, nil
+	}
+
+	isNew, err = os.otpRepo.SetOtpLockedRedis(ctx, username, ttl)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return lockedUntil, isNew, nil
+}
+
 func (os *otpService) generateOTP(length int) (res *string, err error) {
 	if length <= 0 {
 		return nil, fmt.Errorf("length must be positive")
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/helper"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/auth/domain/repository"
+)
+
+const (
+	reportTokenBytes = 32
+
+	// maxUserAgentLength keeps stored user agents within the column size, VARCHAR(512) counts characters
+	maxUserAgentLength = 512
+)
+
+type SecurityService interface {
+	// IsNewDevice records the sign-in and reports whether its IP or user agent wasn't used by the user before
+	IsNewDevice(ctx context.Context, userID int64, ip string, userAgent string) (isNew bool, err error)
+	// CreateReportToken returns a one-time token for the "this wasn't me" link
+	CreateReportToken(ctx context.Context, userID int64) (token string, err error)
+	ConsumeReportToken(ctx context.Context, token string) (userID int64, err error)
+	// RestoreReportToken puts a consumed token back for a full expiration, so a failed report can be retried
+	RestoreReportToken(ctx context.Context, token string, userID int64) (err error)
+}
+
+type securityService struct {
+	securityRepo repository.SecurityRepository
+	config       *config.Watcher
+}
+
+func NewSecurityService(securityRepo repository.SecurityRepository, configWatcher *config.Watcher) (SecurityService, error) {
+	return &securityService{
+		securityRepo: securityRepo,
+		config:       configWatcher,
+	}, nil
+}
+
+func (ss *securityService) IsNewDevice(ctx context.Context, userID int64, ip string, userAgent string) (isNew bool, err error) {
+	userAgent = helper.Truncate(userAgent, maxUserAgentLength)
+
+	history, err := ss.securityRepo.RecordSignInDB(ctx, userID, ip, userAgent)
+	if err != nil {
+		return false, err
+	}
+
+	return history.IsNewDevice(), nil
+}
+
+// CreateReportToken only stores a hash of the token, so tokens can't be used from a Redis dump
+func (ss *securityService) CreateReportToken(ctx context.Context, userID int64) (token string, err error) {
+	buf := make([]byte, reportTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate report token: %v", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	expiration := ss.config.Current().SecurityNotifications.GetReportTokenExpiration()
+
+	err = ss.securityRepo.SetReportTokenRedis(ctx, hashReportToken(token), userID, expiration)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (ss *securityService) ConsumeReportToken(ctx context.Context, token string) (userID int64, err error) {
+	id, err := ss.securityRepo.ConsumeReportTokenRedis(ctx, hashReportToken(token))
+	if err != nil {
+		return 0, err
+	}
+
+	if id == nil {
+		return 0, domainError.ErrInvalidReportToken
+	}
+
+	return *id, nil
+}
+
+func (ss *securityService) RestoreReportToken(ctx context.Context, token string, userID int64) (err error) {
+	expiration := ss.config.Current().SecurityNotifications.GetReportTokenExpiration()
+	return ss.securityRepo.SetReportTokenRedis(ctx, hashReportToken(token), userID, expiration)
+}
+
+func hashReportToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/winartodev/apollo-be/modules/auth/domain/entities"
+	"github.com/winartodev/apollo-be/modules/auth/domain/repository"
+)
+
+// fakeSecurityRepo keeps the user agent of the last recorded sign-in
+type fakeSecurityRepo struct {
+	repository.SecurityRepository
+	userAgent string
+}
+
+func (f *fakeSecurityRepo) RecordSignInDB(ctx context.Context, userID int64, ip string, userAgent string) (*entities.SignInHistory, error) {
+	f.userAgent = userAgent
+	return &entities.SignInHistory{}, nil
+}
+
+func TestIsNewDeviceTruncatesUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{name: "short", userAgent: "Mozilla/5.0", want: "Mozilla/5.0"},
+		{name: "at the limit", userAgent: strings.Repeat("a", maxUserAgentLength), want: strings.Repeat("a", maxUserAgentLength)},
+		{name: "ascii", userAgent: strings.Repeat("a", maxUserAgentLength+10), want: strings.Repeat("a", maxUserAgentLength)},
+		{name: "multi-byte", userAgent: strings.Repeat("é", maxUserAgentLength+1), want: strings.Repeat("é", maxUserAgentLength)},
+		{name: "multi-byte across the byte limit", userAgent: "a" + strings.Repeat("日", maxUserAgentLength), want: "a" + strings.Repeat("日", maxUserAgentLength-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSecurityRepo{}
+			security, err := NewSecurityService(repo, nil)
+			if err != nil {
+				t.Fatalf("NewSecurityService() error = %v", err)
+			}
+
+			if _, err := security.IsNewDevice(context.Background(), 1, "203.0.113.1", tt.userAgent); err != nil {
+				t.Fatalf("IsNewDevice() error = %v", err)
+			}
+
+			if !utf8.ValidString(repo.userAgent) {
+				t.Fatalf("stored user agent %q is not valid UTF-8", repo.userAgent)
+			}
+
+			if repo.userAgent != tt.want {
+				t.Errorf("stored user agent has %d characters, want %d", utf8.RuneCountInString(repo.userAgent), utf8.RuneCountInString(tt.want))
+			}
+		})
+	}
+}
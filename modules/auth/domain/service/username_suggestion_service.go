@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/modules/auth/domain/repository"
+)
+
+const (
+	usernameMinLength = 3
+	usernameMaxLength = 30
+
+	// candidatePoolFactor controls how many candidates are generated per requested suggestion,
+	// so there are still enough left after taken and reserved ones are filtered out.
+	candidatePoolFactor = 4
+)
+
+var (
+	usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.]`)
+	usernamePartSplitter = regexp.MustCompile(`[._\-]+`)
+
+	usernameSeparators = []string{"_", "."}
+	usernamePrefixes   = []string{"the", "real", "its"}
+	usernameSuffixes   = []string{"official", "hq"}
+)
+
+type UsernameSuggestionService interface {
+	SuggestUsernames(ctx context.Context, username string) (res []string, err error)
+}
+
+type usernameSuggestionService struct {
+	authRepo      repository.AuthRepository
+	count         int
+	reservedWords map[string]struct{}
+}
+
+func NewUsernameSuggestionService(authRepo repository.AuthRepository, usernameConfig *config.Username) (UsernameSuggestionService, error) {
+	reservedWords := make(map[string]struct{})
+	for _, word := range usernameConfig.GetReservedWords() {
+		reservedWords[strings.ToLower(word)] = struct{}{}
+	}
+
+	return &usernameSuggestionService{
+		authRepo:      authRepo,
+		count:         usernameConfig.GetSuggestionCount(),
+		reservedWords: reservedWords,
+	}, nil
+}
+
+// SuggestUsernames returns available alternatives for the given username.
+// All candidates are checked against the database with a single query.
+func (uss *usernameSuggestionService) SuggestUsernames(ctx context.Context, username string) (res []string, err error) {
+	base := usernameInvalidChars.ReplaceAllString(username, "")
+	if base == "" || uss.isReserved(base) {
+		return nil, nil
+	}
+
+	candidates := uss.generateCandidates(base)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	existing, err := uss.authRepo.GetExistingUsernamesDB(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[string]struct{}, len(existing))
+	for _, name := range existing {
+		taken[name] = struct{}{}
+	}
+
+	for _, candidate := range candidates {
+		if _, ok := taken[candidate]; ok {
+			continue
+		}
+
+		res = append(res, candidate)
+		if len(res) == uss.count {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (uss *usernameSuggestionService) generateCandidates(base string) []string {
+	poolSize := uss.count * candidatePoolFactor
+	seen := map[string]struct{}{base: {}}
+	candidates := make([]string, 0, poolSize)
+
+	add := func(candidate string) {
+		if len(candidates) >= poolSize {
+			return
+		}
+
+		if len(candidate) < usernameMinLength || len(candidate) > usernameMaxLength {
+			return
+		}
+
+		if _, ok := seen[candidate]; ok {
+			return
+		}
+
+		if uss.isReserved(candidate) {
+			return
+		}
+
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+
+	// Name based variants, e.g. john.doe -> johndoe, jdoe, doe_john
+	parts := splitUsernameParts(base)
+	if len(parts) >= 2 {
+		first, last := parts[0], parts[len(parts)-1]
+		add(first + last)
+		add(first[:1] + last)
+		add(first + last[:1])
+		for _, sep := range usernameSeparators {
+			add(first + sep + last)
+			add(last + sep + first)
+		}
+	}
+
+	// Numeric suffixes with and without separators
+	for i := 0; len(candidates) < poolSize && i < poolSize; i++ {
+		number := randomSuffix(i)
+		add(base + number)
+		add(base + usernameSeparators[i%len(usernameSeparators)] + number)
+	}
+
+	for _, prefix := range usernamePrefixes {
+		add(prefix + base)
+	}
+
+	for _, suffix := range usernameSuffixes {
+		add(base + "_" + suffix)
+	}
+
+	return candidates
+}
+
+func (uss *usernameSuggestionService) isReserved(candidate string) bool {
+	lower := strings.ToLower(candidate)
+	if _, ok := uss.reservedWords[lower]; ok {
+		return true
+	}
+
+	trimmed := strings.TrimRightFunc(lower, func(r rune) bool {
+		return unicode.IsDigit(r) || r == '_' || r == '.'
+	})
+
+	_, ok := uss.reservedWords[trimmed]
+	return ok
+}
+
+// splitUsernameParts splits a username on separators and camel case boundaries.
+func splitUsernameParts(username string) []string {
+	var parts []string
+	for _, segment := range usernamePartSplitter.Split(username, -1) {
+		start := 0
+		runes := []rune(segment)
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+				parts = append(parts, string(runes[start:i]))
+				start = i
+			}
+		}
+
+		if start < len(runes) {
+			parts = append(parts, string(runes[start:]))
+		}
+	}
+
+	return parts
+}
+
+// randomSuffix returns short numbers first and grows longer as more candidates are needed.
+func randomSuffix(attempt int) string {
+	switch {
+	case attempt < 4:
+		return fmt.Sprintf("%d", rand.IntN(90)+10)
+	case attempt < 8:
+		return fmt.Sprintf("%d", rand.IntN(900)+100)
+	default:
+		return fmt.Sprintf("%d", rand.IntN(9000)+1000)
+	}
+}
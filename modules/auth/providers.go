@@ -7,6 +7,9 @@ import (
 	authService "github.com/winartodev/apollo-be/modules/auth/domain/service"
 	authRepo "github.com/winartodev/apollo-be/modules/auth/repository"
 	authUsecase "github.com/winartodev/apollo-be/modules/auth/usecase"
+	countryService "github.com/winartodev/apollo-be/modules/country/domain/service"
+	countryRepo "github.com/winartodev/apollo-be/modules/country/repository"
+	countryUseCase "github.com/winartodev/apollo-be/modules/country/usecase"
 	userService "github.com/winartodev/apollo-be/modules/user/domain/service"
 	userRepo "github.com/winartodev/apollo-be/modules/user/repository"
 	userUseCase "github.com/winartodev/apollo-be/modules/user/usecase"
@@ -16,27 +19,42 @@ var repositorySet = wire.NewSet(
 	// Repository implementations
 	authRepo.NewAuthRepository,
 	authRepo.NewOtpRepository,
+	authRepo.NewInvitationRepository,
+	authRepo.NewSecurityRepository,
 	userRepo.NewUserRepository,
+	countryRepo.NewCountryRepository,
 )
 
 var serviceSet = wire.NewSet(
 	// Domain services
 	authService.NewAuthService,
 	authService.NewOtpService,
+	authService.NewUsernameSuggestionService,
+	authService.NewInvitationService,
+	authService.NewSecurityService,
 	userService.NewUserService,
+	userService.NewPreferenceService,
+	countryService.NewCountryService,
+	countryService.NewCountryLocalizationService,
 )
 
 var useCaseSet = wire.NewSet(
 	// Use cases
 	authUsecase.NewAuthUseCase,
 	authUsecase.NewOtpUseCase,
+	authUsecase.NewInvitationUseCase,
+	authUsecase.NewUserAdminUseCase,
+	authUsecase.NewSecurityNotificationUseCase,
 	userUseCase.NewUserUseCase,
+	userUseCase.NewPreferenceUseCase,
+	countryUseCase.NewCountryUseCase,
 )
 
 var handlerSet = wire.NewSet(
 	// HTTP Handlers
 	authHttp.NewAuthHandler,
 	authHttp.NewOtpHandler,
+	authHttp.NewInvitationHandler,
 )
 
 var moduleSet = wire.NewSet(
@@ -2,8 +2,8 @@ package repository
 
 const (
 	registerUserQuery = `
-		INSERT INTO users (username, email, phone_number, first_name, last_name, password, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+		INSERT INTO users (username, email, phone_number, first_name, last_name, password, is_admin, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
 	`
 
 	updateRefreshTokenQuery = `
@@ -19,11 +19,47 @@ const (
 		    usr.id,
 		    usr.username,
 		    usr.email,
-		    usr.password
+		    usr.password,
+		    usr.is_active,
+		    usr.deleted_at,
+		    usr.token_version
 		FROM users AS usr
 		WHERE usr.username = $1 OR usr.email = $2
 	`
 
+	updateSignInQuery = `
+		UPDATE users
+			SET
+			    refresh_token = $2,
+			    last_login = $3,
+			    updated_at = $3
+		WHERE id = $1 AND is_active = TRUE AND deleted_at IS NULL
+	`
+
+	getExistingUsernamesQuery = `
+		SELECT usr.username
+		FROM users AS usr
+		WHERE usr.username = ANY($1)
+	`
+
+	deactivateUserQuery = `
+		UPDATE users
+			SET
+			    is_active = FALSE,
+			    refresh_token = NULL,
+			    updated_at = $2
+		WHERE id = $1
+	`
+
+	revokeTokensQuery = `
+		UPDATE users
+			SET
+			    refresh_token = NULL,
+			    token_version = token_version + 1,
+			    updated_at = $2
+		WHERE id = $1
+	`
+
 	updatePasswordQueryDB = `
 		UPDATE users SET 
 			password = $2, 
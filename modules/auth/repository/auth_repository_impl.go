@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/winartodev/apollo-be/infrastructure/database"
 	"github.com/winartodev/apollo-be/internal/domain/entities"
 	domainError "github.com/winartodev/apollo-be/internal/domain/error"
@@ -24,22 +25,23 @@ func NewAuthRepository(db *database.Database) (repository.AuthRepository, error)
 }
 
 func (ar *AuthRepositoryImpl) RegisterNewUserDB(ctx context.Context, data entities.SharedUser) (id *int64, err error) {
-	stmt, err := ar.DB.PrepareContext(ctx, registerUserQuery)
+	stmt, err := ar.Conn(ctx).PrepareContext(ctx, registerUserQuery)
 	if err != nil {
 		return nil, fmt.Errorf(database.ErrFailedPrepareStatement, err)
 	}
 
 	defer ar.Database.CloseStatement(stmt, &err)
 
-	createdAt := time.Now().Unix()
+	createdAt := time.Now()
 	var lastInsertID int64
 	err = stmt.QueryRowContext(ctx,
 		data.Username,
 		data.Email,
-		data.PhoneNumber,
+		sql.NullString{String: data.PhoneNumber, Valid: data.PhoneNumber != ""},
 		data.FirstName,
 		data.LastName,
 		data.Password,
+		data.IsAdmin,
 		createdAt,
 	).Scan(&lastInsertID)
 	if err != nil {
@@ -50,14 +52,14 @@ func (ar *AuthRepositoryImpl) RegisterNewUserDB(ctx context.Context, data entiti
 }
 
 func (ar *AuthRepositoryImpl) UpdateRefreshTokenDB(ctx context.Context, id int64, token *string) (err error) {
-	stmt, err := ar.DB.PrepareContext(ctx, updateRefreshTokenQuery)
+	stmt, err := ar.Conn(ctx).PrepareContext(ctx, updateRefreshTokenQuery)
 	if err != nil {
 		return fmt.Errorf(database.ErrFailedPrepareStatement, err)
 	}
 
 	defer ar.Database.CloseStatement(stmt, &err)
 
-	updatedAt := time.Now().Unix()
+	updatedAt := time.Now()
 	_, err = stmt.ExecContext(
 		ctx,
 		id,
@@ -73,11 +75,15 @@ func (ar *AuthRepositoryImpl) UpdateRefreshTokenDB(ctx context.Context, id int64
 
 func (ar *AuthRepositoryImpl) GetUserDataDB(ctx context.Context, username string) (data *entities.SharedUser, err error) {
 	result := &entities.SharedUser{}
-	err = ar.DB.QueryRowContext(ctx, getUserData, username, username).Scan(
+	var deletedAt sql.NullTime
+	err = ar.Conn(ctx).QueryRowContext(ctx, getUserData, username, username).Scan(
 		&result.ID,
 		&result.Username,
 		&result.Email,
 		&result.Password,
+		&result.IsActive,
+		&deletedAt,
+		&result.TokenVersion,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -87,18 +93,93 @@ func (ar *AuthRepositoryImpl) GetUserDataDB(ctx context.Context, username string
 		return nil, domainError.ErrFailedGetUserData
 	}
 
+	result.DeletedAt = database.TimePtr(deletedAt)
+
 	return result, nil
 }
 
+func (ar *AuthRepositoryImpl) UpdateSignInDB(ctx context.Context, id int64, token *string) (err error) {
+	stmt, err := ar.Conn(ctx).PrepareContext(ctx, updateSignInQuery)
+	if err != nil {
+		return fmt.Errorf(database.ErrFailedPrepareStatement, err)
+	}
+
+	defer ar.Database.CloseStatement(stmt, &err)
+
+	lastLogin := time.Now()
+	result, err := stmt.ExecContext(
+		ctx,
+		id,
+		token,
+		lastLogin,
+	)
+	if err != nil {
+		return domainError.ErrFailedUpdateLastLogin
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return domainError.ErrFailedUpdateLastLogin
+	}
+
+	if affected == 0 {
+		return domainError.ErrUserInactive
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) DeactivateUserDB(ctx context.Context, id int64) (err error) {
+	stmt, err := ar.Conn(ctx).PrepareContext(ctx, deactivateUserQuery)
+	if err != nil {
+		return fmt.Errorf(database.ErrFailedPrepareStatement, err)
+	}
+
+	defer ar.Database.CloseStatement(stmt, &err)
+
+	updatedAt := time.Now()
+	_, err = stmt.ExecContext(
+		ctx,
+		id,
+		updatedAt,
+	)
+	if err != nil {
+		return domainError.ErrFailedDeactivateUser
+	}
+
+	return nil
+}
+
+func (ar *AuthRepositoryImpl) RevokeTokensDB(ctx context.Context, id int64) (err error) {
+	stmt, err := ar.Conn(ctx).PrepareContext(ctx, revokeTokensQuery)
+	if err != nil {
+		return fmt.Errorf(database.ErrFailedPrepareStatement, err)
+	}
+
+	defer ar.Database.CloseStatement(stmt, &err)
+
+	updatedAt := time.Now()
+	_, err = stmt.ExecContext(
+		ctx,
+		id,
+		updatedAt,
+	)
+	if err != nil {
+		return domainError.ErrFailedRevokeTokens
+	}
+
+	return nil
+}
+
 func (ar *AuthRepositoryImpl) UpdatePasswordDB(ctx context.Context, id int64, hashedPassword string) (err error) {
-	stmt, err := ar.DB.PrepareContext(ctx, updatePasswordQueryDB)
+	stmt, err := ar.Conn(ctx).PrepareContext(ctx, updatePasswordQueryDB)
 	if err != nil {
 		return fmt.Errorf(database.ErrFailedPrepareStatement, err)
 	}
 
 	defer ar.Database.CloseStatement(stmt, &err)
 
-	updatedAt := time.Now().Unix()
+	updatedAt := time.Now()
 	_, err = stmt.ExecContext(
 		ctx,
 		id,
@@ -111,3 +192,27 @@ func (ar *AuthRepositoryImpl) UpdatePasswordDB(ctx context.Context, id int64, ha
 
 	return err
 }
+
+func (ar *AuthRepositoryImpl) GetExistingUsernamesDB(ctx context.Context, usernames []string) (res []string, err error) {
+	rows, err := ar.Conn(ctx).QueryContext(ctx, getExistingUsernamesQuery, pq.Array(usernames))
+	if err != nil {
+		return nil, domainError.ErrFailedGetUserData
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, domainError.ErrFailedGetUserData
+		}
+
+		res = append(res, username)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, domainError.ErrFailedGetUserData
+	}
+
+	return res, nil
+}
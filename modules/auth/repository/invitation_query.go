@@ -0,0 +1,52 @@
+package repository
+
+const (
+	createInvitationQuery = `
+		INSERT INTO invitations (code, inviter_id, email, max_uses, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
+	`
+
+	getInvitationsByInviterQuery = `
+		SELECT
+		    inv.id,
+		    inv.code,
+		    inv.inviter_id,
+		    COALESCE(inv.email, ''),
+		    inv.max_uses,
+		    inv.used_count,
+		    inv.expires_at,
+		    inv.created_at
+		FROM invitations AS inv
+		WHERE inv.inviter_id = $1 AND inv.revoked_at IS NULL
+		ORDER BY inv.created_at DESC
+	`
+
+	// consumeInvitationQuery checks and increments the usage in a single statement,
+	// so concurrent sign-ups can't use an invitation more times than allowed
+	consumeInvitationQuery = `
+		UPDATE invitations
+			SET used_count = used_count + 1
+		WHERE code = $1
+			AND used_count < max_uses
+			AND expires_at > $2
+			AND revoked_at IS NULL
+			AND (email IS NULL OR LOWER(email) = LOWER($3))
+		RETURNING id, inviter_id, max_uses, used_count, expires_at, created_at
+	`
+
+	// useInviteQuotaQuery decrements the quota of regular users, admins have no limit
+	useInviteQuotaQuery = `
+		UPDATE users
+			SET invite_quota = CASE WHEN is_admin THEN invite_quota ELSE invite_quota - 1 END
+		WHERE id = $1 AND (is_admin = TRUE OR invite_quota > 0)
+	`
+
+	attachInvitationQuery = `
+		UPDATE users
+			SET
+			    invited_by = $2,
+			    invitation_id = $3,
+			    invite_quota = $4
+		WHERE id = $1
+	`
+)
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/winartodev/apollo-be/infrastructure/database"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/auth/domain/entities"
+	"github.com/winartodev/apollo-be/modules/auth/domain/repository"
+)
+
+type InvitationRepositoryImpl struct {
+	*database.Database
+}
+
+func NewInvitationRepository(db *database.Database) (repository.InvitationRepository, error) {
+	return &InvitationRepositoryImpl{
+		Database: db,
+	}, nil
+}
+
+func (ir *InvitationRepositoryImpl) CreateInvitationDB(ctx context.Context, data entities.Invitation) (id *int64, err error) {
+	stmt, err := ir.Conn(ctx).PrepareContext(ctx, createInvitationQuery)
+	if err != nil {
+		return nil, fmt.Errorf(database.ErrFailedPrepareStatement, err)
+	}
+
+	defer ir.Database.CloseStatement(stmt, &err)
+
+	var email sql.NullString
+	if data.Email != "" {
+		email = sql.NullString{String: data.Email, Valid: true}
+	}
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx,
+		data.Code,
+		data.InviterID,
+		email,
+		data.MaxUses,
+		data.ExpiresAt,
+		data.CreatedAt,
+	).Scan(&lastInsertID)
+	if err != nil {
+		return nil, domainError.ErrFailedCreateInvitation
+	}
+
+	return &lastInsertID, nil
+}
+
+func (ir *InvitationRepositoryImpl) GetInvitationsByInviterDB(ctx context.Context, inviterID int64) (res []entities.Invitation, err error) {
+	rows, err := ir.Conn(ctx).QueryContext(ctx, getInvitationsByInviterQuery, inviterID)
+	if err != nil {
+		return nil, domainError.ErrFailedGetInvitation
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var invitation entities.Invitation
+		err = rows.Scan(
+			&invitation.ID,
+			&invitation.Code,
+			&invitation.InviterID,
+			&invitation.Email,
+			&invitation.MaxUses,
+			&invitation.UsedCount,
+			&invitation.ExpiresAt,
+			&invitation.CreatedAt,
+		)
+		if err != nil {
+			return nil, domainError.ErrFailedGetInvitation
+		}
+
+		res = append(res, invitation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, domainError.ErrFailedGetInvitation
+	}
+
+	return res, nil
+}
+
+func (ir *InvitationRepositoryImpl) ConsumeInvitationDB(ctx context.Context, code string, email string) (res *entities.Invitation, err error) {
+	result := &entities.Invitation{
+		Code:  code,
+		Email: email,
+	}
+
+	err = ir.Conn(ctx).QueryRowContext(ctx, consumeInvitationQuery, code, time.Now(), email).Scan(
+		&result.ID,
+		&result.InviterID,
+		&result.MaxUses,
+		&result.UsedCount,
+		&result.ExpiresAt,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, domainError.ErrFailedGetInvitation
+	}
+
+	return result, nil
+}
+
+func (ir *InvitationRepositoryImpl) UseInviteQuotaDB(ctx context.Context, userID int64) (ok bool, err error) {
+	result, err := ir.Conn(ctx).ExecContext(ctx, useInviteQuotaQuery, userID)
+	if err != nil {
+		return false, domainError.ErrFailedCreateInvitation
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, domainError.ErrFailedCreateInvitation
+	}
+
+	return affected > 0, nil
+}
+
+func (ir *InvitationRepositoryImpl) AttachInvitationDB(ctx context.Context, userID int64, invitation *entities.Invitation, inviteQuota int) (err error) {
+	var inviterID, invitationID sql.NullInt64
+	if invitation != nil {
+		invitationID = sql.NullInt64{Int64: invitation.ID, Valid: true}
+		if invitation.InviterID != nil {
+			inviterID = sql.NullInt64{Int64: *invitation.InviterID, Valid: true}
+		}
+	}
+
+	_, err = ir.Conn(ctx).ExecContext(ctx, attachInvitationQuery, userID, inviterID, invitationID, inviteQuota)
+	if err != nil {
+		return domainError.ErrFailedCreateUser
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 const (
 	otpRedisKey         = "otp:%s"
 	otpAttemptsRedisKey = "otp_attempts:%s"
+	otpLockedRedisKey   = "otp_locked:%s"
 )
 
 type OtpRepositoryImpl struct {
@@ -35,12 +36,7 @@ func (r *OtpRepositoryImpl) SetOtpRedis(ctx context.Context, username string, da
 func (r *OtpRepositoryImpl) IncrOtpAttemptRedis(ctx context.Context, username string) (res *int64, err error) {
 	key := fmt.Sprintf(otpAttemptsRedisKey, username)
 
-	val, err := r.Redis.IncrBy(ctx, key, 1)
-	if err != nil {
-		return nil, err
-	}
-
-	err = r.Redis.Expire(ctx, key, 15*time.Minute)
+	val, err := r.Redis.IncrWithExpire(ctx, key, 15*time.Minute)
 	if err != nil {
 		return nil, err
 	}
@@ -75,3 +71,18 @@ func (r *OtpRepositoryImpl) GetOtpAttemptRedis(ctx context.Context, username str
 
 	return res, nil
 }
+
+func (r *OtpRepositoryImpl) GetOtpAttemptTTLRedis(ctx context.Context, username string) (ttl time.Duration, err error) {
+	key := fmt.Sprintf(otpAttemptsRedisKey, username)
+	ttl, err = r.Redis.TTL(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(ttl, 0), nil
+}
+
+func (r *OtpRepositoryImpl) SetOtpLockedRedis(ctx context.Context, username string, exp time.Duration) (isNew bool, err error) {
+	key := fmt.Sprintf(otpLockedRedisKey, username)
+	return r.Redis.SetNX(ctx, key, true, exp)
+}
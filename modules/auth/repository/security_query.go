@@ -0,0 +1,19 @@
+package repository
+
+const (
+	getSignInHistoryQuery = `
+		SELECT
+		    COUNT(*) = 0,
+		    COALESCE(BOOL_OR(usi.ip = $2), FALSE),
+		    COALESCE(BOOL_OR(usi.user_agent = $3), FALSE)
+		FROM user_sign_ins AS usi
+		WHERE usi.user_id = $1
+	`
+
+	upsertSignInQuery = `
+		INSERT INTO user_sign_ins (user_id, ip, user_agent)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, ip, user_agent) DO UPDATE
+			SET last_seen_at = now()
+	`
+)
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/infrastructure/database"
+	redisInfra "github.com/winartodev/apollo-be/infrastructure/redis"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/auth/domain/entities"
+	"github.com/winartodev/apollo-be/modules/auth/domain/repository"
+)
+
+const (
+	reportTokenRedisKey = "security_report:%s"
+)
+
+type SecurityRepositoryImpl struct {
+	*database.Database
+	*redisInfra.Redis
+}
+
+func NewSecurityRepository(db *database.Database, redisClient *redisInfra.Redis) (repository.SecurityRepository, error) {
+	return &SecurityRepositoryImpl{
+		Database: db,
+		Redis:    redisClient,
+	}, nil
+}
+
+func (r *SecurityRepositoryImpl) RecordSignInDB(ctx context.Context, userID int64, ip string, userAgent string) (res *entities.SignInHistory, err error) {
+	history := entities.SignInHistory{}
+	err = r.Conn(ctx).QueryRowContext(ctx, getSignInHistoryQuery, userID, ip, userAgent).Scan(
+		&history.IsFirstSignIn,
+		&history.KnownIP,
+		&history.KnownUserAgent,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainError.ErrFailedRecordSignIn, err)
+	}
+
+	_, err = r.Conn(ctx).ExecContext(ctx, upsertSignInQuery, userID, ip, userAgent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainError.ErrFailedRecordSignIn, err)
+	}
+
+	return &history, nil
+}
+
+func (r *SecurityRepositoryImpl) SetReportTokenRedis(ctx context.Context, tokenHash string, userID int64, exp time.Duration) (err error) {
+	key := fmt.Sprintf(reportTokenRedisKey, tokenHash)
+	return r.Redis.SetEx(ctx, key, userID, exp)
+}
+
+func (r *SecurityRepositoryImpl) ConsumeReportTokenRedis(ctx context.Context, tokenHash string) (userID *int64, err error) {
+	key := fmt.Sprintf(reportTokenRedisKey, tokenHash)
+	err = r.Redis.GetDel(ctx, key, &userID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return userID, nil
+}
@@ -3,14 +3,21 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+
 	"github.com/winartodev/apollo-be/helper"
 
 	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/infrastructure/phone"
+	"github.com/winartodev/apollo-be/infrastructure/tracing"
 	"github.com/winartodev/apollo-be/internal/domain"
 	domainEntity "github.com/winartodev/apollo-be/internal/domain/entities"
 	domainError "github.com/winartodev/apollo-be/internal/domain/error"
 	authService "github.com/winartodev/apollo-be/modules/auth/domain/service"
 	"github.com/winartodev/apollo-be/modules/auth/usecase/dto"
+	countryUseCase "github.com/winartodev/apollo-be/modules/country/usecase"
 	userUseCase "github.com/winartodev/apollo-be/modules/user/usecase"
 )
 
@@ -22,30 +29,69 @@ type AuthUseCase interface {
 	VerifyUser(ctx context.Context, username string) (res *dto.VerifyUserDto, err error)
 	RequestResetPassword(ctx context.Context, email string) (res *dto.AuthDto, err error)
 	ResetPassword(ctx context.Context, data dto.ResetPasswordDto) (err error)
+	ReportActivity(ctx context.Context, token string) (res *dto.AuthDto, err error)
 }
 
 type authUseCase struct {
-	jwt         domain.TokenService
-	userUseCase userUseCase.UserUseCase
-	authService authService.AuthService
-	otpUseCase  OtpUseCase
+	jwt               domain.TokenService
+	userUseCase       userUseCase.UserUseCase
+	authService       authService.AuthService
+	suggestionService authService.UsernameSuggestionService
+	invitationService authService.InvitationService
+	securityService   authService.SecurityService
+	otpUseCase        OtpUseCase
+	countryUseCase    countryUseCase.CountryUseCase
+	phoneService      phone.PhoneNumberService
+	userStatus        domain.UserStatusService
+	metrics           domain.Metrics
+	transactor        domain.Transactor
+	events            domain.EventPublisher
+	logger            *slog.Logger
 }
 
-func NewAuthUseCase(authService authService.AuthService, otpUseCase OtpUseCase, jwt domain.TokenService, userUseCase userUseCase.UserUseCase) (AuthUseCase, error) {
+// failureReasonInternal is recorded for failures that aren't caused by the client
+const failureReasonInternal = "internal"
+
+// signInFailureReasons are the errors counted by name, anything else is counted as internal
+var signInFailureReasons = []error{
+	domainError.ErrUserNotFound,
+	domainError.ErrInvalidUsernameOrPassword,
+	domainError.ErrUserInactive,
+}
+
+func NewAuthUseCase(authService authService.AuthService, suggestionService authService.UsernameSuggestionService, invitationService authService.InvitationService, securityService authService.SecurityService, otpUseCase OtpUseCase, countryUseCase countryUseCase.CountryUseCase, phoneService phone.PhoneNumberService, jwt domain.TokenService, userUseCase userUseCase.UserUseCase, userStatus domain.UserStatusService, metrics domain.Metrics, transactor domain.Transactor, events domain.EventPublisher, appLogger *logger.Logger) (AuthUseCase, error) {
 	return &authUseCase{
-		jwt:         jwt,
-		userUseCase: userUseCase,
-		authService: authService,
-		otpUseCase:  otpUseCase,
+		jwt:               jwt,
+		userUseCase:       userUseCase,
+		authService:       authService,
+		suggestionService: suggestionService,
+		invitationService: invitationService,
+		securityService:   securityService,
+		otpUseCase:        otpUseCase,
+		countryUseCase:    countryUseCase,
+		phoneService:      phoneService,
+		userStatus:        userStatus,
+		metrics:           metrics,
+		transactor:        transactor,
+		events:            events,
+		logger:            appLogger.Named("auth"),
 	}, nil
 }
 
 func (uc *authUseCase) SignUp(ctx context.Context, data dto.SignUpDto) (res *dto.AuthDto, err error) {
+	ctx, span := tracer.Start(ctx, "AuthUseCase.SignUp")
+	defer func() { tracing.End(span, err) }()
+
+	phoneNumber, err := uc.normalizePhoneNumber(ctx, data.PhoneNumber, data.CountryCode)
+	if err != nil {
+		return nil, err
+	}
+
 	var sharedUser = &domainEntity.SharedUser{
 		Username:    data.Username,
 		Password:    data.Password,
 		Email:       data.Email,
-		PhoneNumber: data.PhoneNumber,
+		PhoneNumber: phoneNumber,
 	}
 
 	user, err := uc.userUseCase.CheckUserIfExists(ctx, *sharedUser)
@@ -57,16 +103,38 @@ func (uc *authUseCase) SignUp(ctx context.Context, data dto.SignUpDto) (res *dto
 		return nil, domainError.ErrUserAlreadyExists
 	}
 
-	newUser, err := uc.authService.CreateNewUser(ctx, *sharedUser)
+	// The user, its invitation and the OTP email are committed together, a failure releases the invitation
+	var newUser *domainEntity.SharedUser
+	var otp *dto.OtpDto
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		invitation, err := uc.invitationService.AuthorizeRegistration(ctx, data.Email, data.InviteCode)
+		if err != nil {
+			return err
+		}
+
+		newUser, err = uc.authService.CreateNewUser(ctx, *sharedUser)
+		if err != nil {
+			return err
+		}
+
+		err = uc.invitationService.AttachInvitation(ctx, newUser.ID, invitation)
+		if err != nil {
+			return err
+		}
+
+		otp, err = uc.otpUseCase.SendOTP(context.WithValue(ctx, infraContext.UserIdKey, newUser.ID))
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountSignedUp, UserID: newUser.ID})
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	uc.metrics.SignUp()
 	ctx = context.WithValue(ctx, infraContext.UserIdKey, newUser.ID)
-	otp, err := uc.otpUseCase.SendOTP(ctx)
-	if err != nil {
-		return nil, err
-	}
 
 	domainSharedUser := &domainEntity.SharedUser{
 		ID:       newUser.ID,
@@ -92,15 +160,21 @@ func (uc *authUseCase) SignUp(ctx context.Context, data dto.SignUpDto) (res *dto
 }
 
 func (uc *authUseCase) SignIn(ctx context.Context, data dto.SignInDto) (res *dto.AuthDto, err error) {
+	ctx, span := tracer.Start(ctx, "AuthUseCase.SignIn")
+	defer func() { tracing.End(span, err) }()
+
 	user, err := uc.authService.VerifyUsernameAndPassword(ctx, data.Username, data.Password)
 	if err != nil {
+		uc.signInFailed(ctx, data.Username, user, err)
 		return nil, err
 	}
 
+	// Tokens carry the current version, tokens issued before a revocation are rejected
 	sharedUser := &domainEntity.SharedUser{
-		ID:       user.ID,
-		Username: user.Username,
-		Email:    user.Email,
+		ID:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		TokenVersion: user.TokenVersion,
 	}
 
 	jwt, err := uc.jwt.GenerateTokenPair(sharedUser)
@@ -108,8 +182,17 @@ func (uc *authUseCase) SignIn(ctx context.Context, data dto.SignInDto) (res *dto
 		return nil, err
 	}
 
-	err = uc.authService.UpdateRefreshToken(ctx, user.ID, &jwt.RefreshToken)
+	// A new device notification is only sent for a sign-in that was recorded
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := uc.authService.RecordSignIn(ctx, user.ID, &jwt.RefreshToken)
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountSignedIn, UserID: user.ID})
+	})
 	if err != nil {
+		uc.signInFailed(ctx, data.Username, user, err)
 		return nil, err
 	}
 
@@ -120,12 +203,22 @@ func (uc *authUseCase) SignIn(ctx context.Context, data dto.SignInDto) (res *dto
 }
 
 func (uc *authUseCase) SignOut(ctx context.Context) (res *dto.AuthDto, err error) {
+	ctx, span := tracer.Start(ctx, "AuthUseCase.SignOut")
+	defer func() { tracing.End(span, err) }()
+
 	id, err := infraContext.GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	err = uc.authService.UpdateRefreshToken(ctx, id, nil)
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := uc.authService.UpdateRefreshToken(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountSignedOut, UserID: id})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -134,15 +227,24 @@ func (uc *authUseCase) SignOut(ctx context.Context) (res *dto.AuthDto, err error
 }
 
 func (uc *authUseCase) RefreshToken(ctx context.Context) (res *dto.AuthDto, err error) {
+	ctx, span := tracer.Start(ctx, "AuthUseCase.RefreshToken")
+	defer func() { tracing.End(span, err) }()
+
 	user, err := uc.userUseCase.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	tokenVersion, err := uc.userStatus.GetTokenVersion(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	sharedUser := &domainEntity.SharedUser{
-		ID:       user.ID,
-		Username: user.Username,
-		Email:    user.Email,
+		ID:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		TokenVersion: tokenVersion,
 	}
 
 	jwt, err := uc.jwt.GenerateTokenPair(sharedUser)
@@ -150,11 +252,20 @@ func (uc *authUseCase) RefreshToken(ctx context.Context) (res *dto.AuthDto, err
 		return nil, err
 	}
 
-	err = uc.authService.UpdateRefreshToken(ctx, user.ID, &jwt.RefreshToken)
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := uc.authService.UpdateRefreshToken(ctx, user.ID, &jwt.RefreshToken)
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountTokenRefreshed, UserID: user.ID})
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	uc.metrics.TokenRefreshed()
+
 	return &dto.AuthDto{
 		AccessToken:  jwt.AccessToken,
 		RefreshToken: jwt.RefreshToken,
@@ -162,6 +273,9 @@ func (uc *authUseCase) RefreshToken(ctx context.Context) (res *dto.AuthDto, err
 }
 
 func (uc *authUseCase) VerifyUser(ctx context.Context, username string) (res *dto.VerifyUserDto, err error) {
+	ctx, span := tracer.Start(ctx, "AuthUseCase.VerifyUser")
+	defer func() { tracing.End(span, err) }()
+
 	var sharedUser domainEntity.SharedUser
 
 	if helper.IsEmailValid(username) {
@@ -175,12 +289,24 @@ func (uc *authUseCase) VerifyUser(ctx context.Context, username string) (res *dt
 		return nil, err
 	}
 
+	var suggestions []string
+	if errors.Is(err, domainError.ErrUsernameAlreadyExists) {
+		suggestions, err = uc.suggestionService.SuggestUsernames(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &dto.VerifyUserDto{
-		User: user,
+		User:        user,
+		Suggestions: suggestions,
 	}, nil
 }
 
 func (uc *authUseCase) RequestResetPassword(ctx context.Context, email string) (res *dto.AuthDto, err error) {
+	ctx, span := tracer.Start(ctx, "AuthUseCase.RequestResetPassword")
+	defer func() { tracing.End(span, err) }()
+
 	if !helper.IsEmailValid(email) {
 		return nil, domainError.ErrInvalidEmail
 	}
@@ -190,8 +316,15 @@ func (uc *authUseCase) RequestResetPassword(ctx context.Context, email string) (
 		return nil, err
 	}
 
-	ctx = context.WithValue(ctx, infraContext.UserIdKey, user.ID)
-	otp, err := uc.otpUseCase.SendOTP(ctx)
+	var otp *dto.OtpDto
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		otp, err = uc.otpUseCase.SendOTP(context.WithValue(ctx, infraContext.UserIdKey, user.ID))
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountPasswordResetRequested, UserID: user.ID})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +335,9 @@ func (uc *authUseCase) RequestResetPassword(ctx context.Context, email string) (
 }
 
 func (uc *authUseCase) ResetPassword(ctx context.Context, data dto.ResetPasswordDto) (err error) {
+	ctx, span := tracer.Start(ctx, "AuthUseCase.ResetPassword")
+	defer func() { tracing.End(span, err) }()
+
 	if !uc.comparePassword(data.Password, data.PasswordConfirmation) {
 		return domainError.ErrPasswordConfirmationMismatch
 	}
@@ -215,9 +351,136 @@ func (uc *authUseCase) ResetPassword(ctx context.Context, data dto.ResetPassword
 		return err
 	}
 
-	return uc.authService.UpdatePassword(ctx, user.ID, data.Password)
+	return uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := uc.authService.UpdatePassword(ctx, user.ID, data.Password)
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountPasswordChanged, UserID: user.ID})
+	})
+}
+
+// ReportActivity handles the "this wasn't me" link of security notifications: the user is signed out
+// and a password reset code is sent, as if they had requested a reset
+func (uc *authUseCase) ReportActivity(ctx context.Context, token string) (res *dto.AuthDto, err error) {
+	ctx, span := tracer.Start(ctx, "AuthUseCase.ReportActivity")
+	defer func() { tracing.End(span, err) }()
+
+	// Consuming the token first lets only one of concurrent reports through, it is restored when the report fails
+	userID, err := uc.securityService.ConsumeReportToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var otp *dto.OtpDto
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := uc.authService.RevokeTokens(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		otp, err = uc.otpUseCase.SendOTP(context.WithValue(ctx, infraContext.UserIdKey, userID))
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountActivityReported, UserID: userID})
+	})
+	if err != nil {
+		if restoreErr := uc.securityService.RestoreReportToken(context.WithoutCancel(ctx), token, userID); restoreErr != nil {
+			uc.logger.WarnContext(ctx, "failed to restore report token", "user_id", userID, "error", restoreErr)
+		}
+
+		return nil, err
+	}
+
+	// The cached status still has the previous token version, access tokens are rejected once it is dropped
+	if err := uc.userStatus.InvalidateUserStatus(ctx, userID); err != nil {
+		uc.logger.WarnContext(ctx, "failed to invalidate user status, access tokens stay valid until it expires", "user_id", userID, "error", err)
+	}
+
+	return &dto.AuthDto{
+		Otp: otp,
+	}, nil
 }
 
 func (uc *authUseCase) comparePassword(password string, passwordConfirmation string) bool {
 	return password == passwordConfirmation
 }
+
+// normalizePhoneNumber converts the number to E.164 so the same number in different formats maps to one user.
+// The country code may be any ISO 3166-1 code, the configured default region is used when it's empty.
+func (uc *authUseCase) normalizePhoneNumber(ctx context.Context, number string, countryCode string) (res string, err error) {
+	if number == "" {
+		return "", nil
+	}
+
+	region := ""
+	if countryCode != "" {
+		country, err := uc.countryUseCase.GetCountry(ctx, countryCode, "")
+		if err != nil {
+			if errors.Is(err, domainError.ErrCountryNotFound) {
+				return "", domainError.ErrInvalidCountryCode
+			}
+
+			return "", err
+		}
+
+		region = country.Alpha2
+	}
+
+	res, err = uc.phoneService.Normalize(number, region)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domainError.ErrInvalidPhoneNumber, err)
+	}
+
+	return res, nil
+}
+
+// signInFailed counts and records the failed attempt, user is nil when the username is unknown
+func (uc *authUseCase) signInFailed(ctx context.Context, identifier string, user *domainEntity.SharedUser, err error) {
+	reason := signInFailureReason(err)
+	uc.metrics.SignInFailed(reason)
+
+	event := domain.AccountEvent{
+		Type: domain.AccountSignedIn,
+		Metadata: map[string]string{
+			domain.EventMetadataIdentifier: identifier,
+		},
+	}
+
+	if user != nil {
+		event.UserID = user.ID
+	}
+
+	publishFailure(ctx, uc.events, event, reason)
+}
+
+// publishFailure publishes a failed attempt. The caller returns the attempt's error, so an error of a subscriber
+// is dropped, subscribers log their own failures.
+func publishFailure(ctx context.Context, events domain.EventPublisher, event domain.AccountEvent, reason string) {
+	event.Result = domain.EventFailed
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]string)
+	}
+
+	event.Metadata[domain.EventMetadataReason] = reason
+	_ = events.Publish(ctx, event)
+}
+
+func signInFailureReason(err error) string {
+	return failureReason(err, signInFailureReasons)
+}
+
+// failureReason returns the name of the first known error err wraps, anything else is internal.
+// Raw errors are never recorded, they may hold queries, addresses or other details.
+func failureReason(err error, reasons []error) string {
+	for _, reason := range reasons {
+		if errors.Is(err, reason) {
+			return reason.Error()
+		}
+	}
+
+	return failureReasonInternal
+}
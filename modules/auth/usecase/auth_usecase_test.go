@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/winartodev/apollo-be/config"
+	infraAuth "github.com/winartodev/apollo-be/infrastructure/auth"
+	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/internal/domain"
+	domainEntity "github.com/winartodev/apollo-be/internal/domain/entities"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/auth/domain/service"
+	"github.com/winartodev/apollo-be/modules/auth/usecase/dto"
+	userUseCase "github.com/winartodev/apollo-be/modules/user/usecase"
+	userDto "github.com/winartodev/apollo-be/modules/user/usecase/dto"
+)
+
+const (
+	testUsername = "jane"
+	testPassword = "Secret123!"
+)
+
+// userStore is the users table shared by the fakes, it keeps the token version like the users.token_version column
+type userStore struct {
+	user     domainEntity.SharedUser
+	password string
+}
+
+// fakeAuthService implements the auth service over the store, RevokeTokens bumps the version like revokeTokensQuery
+type fakeAuthService struct {
+	service.AuthService
+	store *userStore
+}
+
+func (f *fakeAuthService) VerifyUsernameAndPassword(ctx context.Context, username string, password string) (*domainEntity.SharedUser, error) {
+	if username != f.store.user.Username {
+		return nil, domainError.ErrUserNotFound
+	}
+
+	user := f.store.user
+	if password != f.store.password {
+		return &user, domainError.ErrInvalidUsernameOrPassword
+	}
+
+	return &user, nil
+}
+
+func (f *fakeAuthService) GetUserByIdentifier(ctx context.Context, identifier string) (*domainEntity.SharedUser, error) {
+	if identifier != f.store.user.Username {
+		return nil, domainError.ErrUserNotFound
+	}
+
+	user := f.store.user
+	return &user, nil
+}
+
+func (f *fakeAuthService) RecordSignIn(ctx context.Context, id int64, token *string) error {
+	return nil
+}
+
+func (f *fakeAuthService) UpdateRefreshToken(ctx context.Context, id int64, token *string) error {
+	return nil
+}
+
+func (f *fakeAuthService) UpdatePassword(ctx context.Context, id int64, password string) error {
+	f.store.password = password
+	return nil
+}
+
+func (f *fakeAuthService) RevokeTokens(ctx context.Context, id int64) error {
+	f.store.user.TokenVersion++
+	return nil
+}
+
+// fakeUserStatus reads the store without a cache and authorizes tokens like UserStatusService
+type fakeUserStatus struct {
+	domain.UserStatusService
+	store *userStore
+}
+
+func (f *fakeUserStatus) AuthorizeToken(ctx context.Context, userID int64, tokenVersion int64) error {
+	if tokenVersion != f.store.user.TokenVersion {
+		return domainError.ErrTokenRevoked
+	}
+
+	return nil
+}
+
+func (f *fakeUserStatus) GetTokenVersion(ctx context.Context, userID int64) (int64, error) {
+	return f.store.user.TokenVersion, nil
+}
+
+func (f *fakeUserStatus) InvalidateUserStatus(ctx context.Context, userID int64) error {
+	return nil
+}
+
+type fakeUserUseCase struct {
+	userUseCase.UserUseCase
+	store *userStore
+}
+
+func (f *fakeUserUseCase) GetCurrentUser(ctx context.Context) (*userDto.UserDto, error) {
+	return &userDto.UserDto{ID: f.store.user.ID, Username: f.store.user.Username, Email: f.store.user.Email}, nil
+}
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (fakeTransactor) WithSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeEvents struct{}
+
+func (fakeEvents) Publish(ctx context.Context, event domain.AccountEvent) error {
+	return nil
+}
+
+type fakeMetrics struct {
+	domain.Metrics
+}
+
+func (fakeMetrics) SignInFailed(reason string) {}
+
+func (fakeMetrics) TokenRefreshed() {}
+
+// authTest wires the use cases to the fakes and a real JWT service
+type authTest struct {
+	store       *userStore
+	authService *fakeAuthService
+	tokens      domain.TokenService
+	userStatus  *fakeUserStatus
+	auth        AuthUseCase
+}
+
+func newAuthTest(t *testing.T) *authTest {
+	t.Helper()
+
+	store := &userStore{
+		user:     domainEntity.SharedUser{ID: 7, Username: testUsername, Email: "jane@example.com"},
+		password: testPassword,
+	}
+
+	jwt, err := infraAuth.NewJWT(&config.Jwt{AccessTokenSecret: "access-secret", RefreshTokenSecret: "refresh-secret"})
+	if err != nil {
+		t.Fatalf("NewJWT() error = %v", err)
+	}
+
+	tokens := infraAuth.NewJwtTokenService(jwt)
+	authService := &fakeAuthService{store: store}
+	userStatus := &fakeUserStatus{store: store}
+	users := &fakeUserUseCase{store: store}
+	appLogger := logger.NewLogger(&config.Logging{}, config.EnvDevelopment)
+
+	auth, err := NewAuthUseCase(authService, nil, nil, nil, nil, nil, nil, tokens, users, userStatus, fakeMetrics{}, fakeTransactor{}, fakeEvents{}, appLogger)
+	if err != nil {
+		t.Fatalf("NewAuthUseCase() error = %v", err)
+	}
+
+	return &authTest{store: store, authService: authService, tokens: tokens, userStatus: userStatus, auth: auth}
+}
+
+func (at *authTest) signIn(t *testing.T, password string) *dto.AuthDto {
+	t.Helper()
+
+	res, err := at.auth.SignIn(context.Background(), signInDto(password))
+	if err != nil {
+		t.Fatalf("SignIn() error = %v", err)
+	}
+
+	return res
+}
+
+func signInDto(password string) dto.SignInDto {
+	return dto.SignInDto{Username: testUsername, Password: password}
+}
+
+// authorize checks the access token like the auth middleware does for an authorized request
+func (at *authTest) authorize(token string) error {
+	claims, err := at.tokens.ValidateAccessToken(token)
+	if err != nil {
+		return err
+	}
+
+	return at.userStatus.AuthorizeToken(context.Background(), claims.UserID, claims.TokenVersion)
+}
+
+func TestSignInIssuesTokensWithTheCurrentVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		revokes  int
+		wantVer  int64
+		checkOld bool
+	}{
+		{name: "never revoked", wantVer: 0},
+		{name: "revoked once", revokes: 1, wantVer: 1, checkOld: true},
+		{name: "revoked twice", revokes: 2, wantVer: 2, checkOld: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := newAuthTest(t)
+			before := at.signIn(t, testPassword)
+
+			for i := 0; i < tt.revokes; i++ {
+				if err := at.authService.RevokeTokens(context.Background(), at.store.user.ID); err != nil {
+					t.Fatalf("RevokeTokens() error = %v", err)
+				}
+			}
+
+			after := at.signIn(t, testPassword)
+
+			claims, err := at.tokens.ValidateAccessToken(after.AccessToken)
+			if err != nil {
+				t.Fatalf("ValidateAccessToken() error = %v", err)
+			}
+
+			if claims.TokenVersion != tt.wantVer {
+				t.Errorf("access token version = %d, want %d", claims.TokenVersion, tt.wantVer)
+			}
+
+			if err := at.authorize(after.AccessToken); err != nil {
+				t.Errorf("authorizing the new access token error = %v, want nil", err)
+			}
+
+			if tt.checkOld {
+				if err := at.authorize(before.AccessToken); !errors.Is(err, domainError.ErrTokenRevoked) {
+					t.Errorf("authorizing the revoked access token error = %v, want %v", err, domainError.ErrTokenRevoked)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshTokenIssuesTokensWithTheCurrentVersion(t *testing.T) {
+	at := newAuthTest(t)
+	at.signIn(t, testPassword)
+
+	if err := at.authService.RevokeTokens(context.Background(), at.store.user.ID); err != nil {
+		t.Fatalf("RevokeTokens() error = %v", err)
+	}
+
+	// The refresh token issued after the revocation, the middleware rejects the older ones
+	signedIn := at.signIn(t, testPassword)
+	refreshClaims, err := at.tokens.ValidateRefreshToken(signedIn.RefreshToken)
+	if err != nil {
+		t.Fatalf("ValidateRefreshToken() error = %v", err)
+	}
+
+	if err := at.userStatus.AuthorizeToken(context.Background(), refreshClaims.UserID, refreshClaims.TokenVersion); err != nil {
+		t.Fatalf("authorizing the refresh token error = %v, want nil", err)
+	}
+
+	ctx := context.WithValue(context.Background(), infraContext.UserIdKey, at.store.user.ID)
+	refreshed, err := at.auth.RefreshToken(ctx)
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+
+	if err := at.authorize(refreshed.AccessToken); err != nil {
+		t.Errorf("authorizing the refreshed access token error = %v, want nil", err)
+	}
+}
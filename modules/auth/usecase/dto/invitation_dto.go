@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type InvitationDto struct {
+	Code      string
+	Email     string
+	Link      string
+	MaxUses   int
+	UsedCount int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type CreateInvitationDto struct {
+	Email     string
+	MaxUses   int
+	ExpiresIn int64
+}
@@ -5,4 +5,6 @@ type SignUpDto struct {
 	Password    string
 	Email       string
 	PhoneNumber string
+	CountryCode string
+	InviteCode  string
 }
@@ -0,0 +1,18 @@
+package dto
+
+type CreateUserDto struct {
+	Username    string
+	Email       string
+	Password    string
+	PhoneNumber string
+	FirstName   string
+	LastName    string
+	IsAdmin     bool
+}
+
+type UserAdminDto struct {
+	ID       int64
+	Username string
+	Email    string
+	IsAdmin  bool
+}
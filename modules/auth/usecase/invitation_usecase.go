@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	"github.com/winartodev/apollo-be/infrastructure/smtp"
+	"github.com/winartodev/apollo-be/internal/domain"
+	domainEntity "github.com/winartodev/apollo-be/internal/domain/entities"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/auth/domain/entities"
+	"github.com/winartodev/apollo-be/modules/auth/domain/service"
+	"github.com/winartodev/apollo-be/modules/auth/usecase/dto"
+	userUseCase "github.com/winartodev/apollo-be/modules/user/usecase"
+)
+
+type InvitationUseCase interface {
+	CreateInvitation(ctx context.Context, data dto.CreateInvitationDto) (res *dto.InvitationDto, err error)
+	GetInvitations(ctx context.Context) (res []dto.InvitationDto, err error)
+}
+
+type invitationUseCase struct {
+	emailQueue        smtp.EmailQueue
+	templateService   smtp.TemplateService
+	config            *config.Watcher
+	userUseCase       userUseCase.UserUseCase
+	invitationService service.InvitationService
+	transactor        domain.Transactor
+}
+
+func NewInvitationUseCase(invitationService service.InvitationService, userUseCase userUseCase.UserUseCase, emailQueue smtp.EmailQueue, templateService smtp.TemplateService, configWatcher *config.Watcher, transactor domain.Transactor) (InvitationUseCase, error) {
+	return &invitationUseCase{
+		emailQueue:        emailQueue,
+		templateService:   templateService,
+		config:            configWatcher,
+		userUseCase:       userUseCase,
+		invitationService: invitationService,
+		transactor:        transactor,
+	}, nil
+}
+
+func (iu *invitationUseCase) CreateInvitation(ctx context.Context, data dto.CreateInvitationDto) (res *dto.InvitationDto, err error) {
+	if !iu.config.Current().IsFeatureEnabled(config.FeatureInvitations) {
+		return nil, domainError.ErrInvitationsDisabled
+	}
+
+	user, err := iu.userUseCase.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Email != "" {
+		existing, err := iu.userUseCase.CheckUserIfExists(ctx, domainEntity.SharedUser{Email: data.Email})
+		if err != nil {
+			return nil, err
+		}
+
+		if existing != nil {
+			return nil, domainError.ErrEmailAlreadyExists
+		}
+	}
+
+	// The quota, the invitation and its email are committed together
+	err = iu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		invitation, err := iu.invitationService.CreateInvitation(ctx, user.ID, data.Email, data.MaxUses, time.Duration(data.ExpiresIn)*time.Second)
+		if err != nil {
+			return err
+		}
+
+		res = iu.buildInvitationDto(invitation)
+
+		if data.Email == "" {
+			return nil
+		}
+
+		return iu.sendInvitationEmail(ctx, data.Email, user.Username, res)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (iu *invitationUseCase) GetInvitations(ctx context.Context) (res []dto.InvitationDto, err error) {
+	userID, err := infraContext.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := iu.invitationService.GetInvitations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res = make([]dto.InvitationDto, 0, len(invitations))
+	for i := range invitations {
+		res = append(res, *iu.buildInvitationDto(&invitations[i]))
+	}
+
+	return res, nil
+}
+
+func (iu *invitationUseCase) buildInvitationDto(invitation *entities.Invitation) *dto.InvitationDto {
+	invitationDto := invitation.ToUseCaseData()
+	if inviteURL := iu.config.Current().Registration.InviteURL; inviteURL != "" {
+		invitationDto.Link = fmt.Sprintf(inviteURL, invitation.Code)
+	}
+
+	return &invitationDto
+}
+
+func (iu *invitationUseCase) sendInvitationEmail(ctx context.Context, email string, inviter string, invitation *dto.InvitationDto) (err error) {
+	data := make(map[string]interface{})
+	data["inviter"] = inviter
+	data["code"] = invitation.Code
+	data["link"] = invitation.Link
+	data["expiresAt"] = invitation.ExpiresAt.UTC().Format(time.RFC1123)
+
+	// The invitee has no preferences yet, the inviter's request language is the best guess
+	locale := iu.templateService.ResolveLocale(infraContext.GetAcceptLanguageFromContext(ctx))
+
+	body, err := iu.templateService.Render(smtp.InvitationEmail, locale, data)
+	if err != nil {
+		return err
+	}
+
+	err = iu.emailQueue.Enqueue(ctx, email, body)
+	if err != nil {
+		return fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	return nil
+}
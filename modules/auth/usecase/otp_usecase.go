@@ -1,20 +1,25 @@
 package usecase
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"html/template"
-	"path/filepath"
-	"runtime"
-
-	"github.com/labstack/gommon/log"
+	"log/slog"
+	"math"
+	"time"
 
 	"github.com/winartodev/apollo-be/config"
+	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
 	"github.com/winartodev/apollo-be/infrastructure/smtp"
+	"github.com/winartodev/apollo-be/infrastructure/tracing"
+	"github.com/winartodev/apollo-be/internal/domain"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/auth/domain/enums"
 	"github.com/winartodev/apollo-be/modules/auth/domain/service"
 	"github.com/winartodev/apollo-be/modules/auth/usecase/dto"
 	userUseCase "github.com/winartodev/apollo-be/modules/user/usecase"
+	userDto "github.com/winartodev/apollo-be/modules/user/usecase/dto"
 )
 
 type OtpUseCase interface {
@@ -22,53 +27,106 @@ type OtpUseCase interface {
 	ValidateOTP(ctx context.Context, code string) (res *dto.OtpDto, err error)
 }
 
+// otpFailureReasons are the validation errors recorded by name, anything else is recorded as internal
+var otpFailureReasons = []error{
+	domainError.ErrInvalidOTPNumber,
+}
+
 type otpUseCase struct {
-	smtpService smtp.SMTPService
-	otp         *config.Otp
-	userUseCase userUseCase.UserUseCase
-	otpService  service.OtpService
+	emailQueue        smtp.EmailQueue
+	templateService   smtp.TemplateService
+	config            *config.Watcher
+	userUseCase       userUseCase.UserUseCase
+	preferenceUseCase userUseCase.PreferenceUseCase
+	otpService        service.OtpService
+	metrics           domain.Metrics
+	events            domain.EventPublisher
+	logger            *slog.Logger
 }
 
-func NewOtpUseCase(otpService service.OtpService, userUseCase userUseCase.UserUseCase, smtpService smtp.SMTPService, otp *config.Otp) OtpUseCase {
+func NewOtpUseCase(otpService service.OtpService, userUseCase userUseCase.UserUseCase, preferenceUseCase userUseCase.PreferenceUseCase, emailQueue smtp.EmailQueue, templateService smtp.TemplateService, configWatcher *config.Watcher, metrics domain.Metrics, events domain.EventPublisher, appLogger *logger.Logger) OtpUseCase {
 	return &otpUseCase{
-		smtpService: smtpService,
-		otp:         otp,
-		otpService:  otpService,
-		userUseCase: userUseCase,
+		emailQueue:        emailQueue,
+		templateService:   templateService,
+		config:            configWatcher,
+		otpService:        otpService,
+		userUseCase:       userUseCase,
+		preferenceUseCase: preferenceUseCase,
+		metrics:           metrics,
+		events:            events,
+		logger:            appLogger.Named("auth"),
 	}
 }
 
 func (ou *otpUseCase) SendOTP(ctx context.Context) (res *dto.OtpDto, err error) {
+	ctx, span := tracer.Start(ctx, "OtpUseCase.SendOTP")
+	defer func() { tracing.End(span, err) }()
+
 	user, err := ou.userUseCase.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	otp, retryLeft, err := ou.otpService.GetOTP(ctx, user.Email)
+	if err != nil {
+		if errors.Is(err, domainError.ErrOtpTooManyRequest) {
+			ou.publishLocked(ctx, user.ID, user.Email)
+		}
+
+		return nil, err
+	}
+
+	preferences := ou.getPreferences(ctx, user.ID)
+	// SMS can't be chosen until there is an SMS transport, a preference stored before that gets the email
+	if ou.resolveOtpMethod(preferences) == enums.SMS {
+		ou.logger.WarnContext(ctx, "sms otp channel is not available, sending the otp by email", "user_id", user.ID)
+	}
+
+	err = ou.sendOTPEmail(ctx, user.Email, *otp, ou.resolveLocale(ctx, preferences))
 	if err != nil {
 		return nil, err
 	}
 
-	ou.sendOTPEmailAsync(user.Email, *otp)
+	ou.metrics.OtpSent(enums.Email.String())
+
+	err = ou.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountOtpSent, UserID: user.ID})
+	if err != nil {
+		return nil, err
+	}
 
-	retryAttemptsLeft := ou.otp.MaxAttempt - *retryLeft
+	otpConfig := ou.config.Current().OTP
+	retryAttemptsLeft := otpConfig.GetMaxAttempts() - *retryLeft
+	expiresIn := int64(otpConfig.GetExpiration().Seconds())
 
 	return &dto.OtpDto{
-		ExpiresIn:         ou.otp.Expiration,
-		RetryAfterIn:      ou.otp.Expiration,
+		ExpiresIn:         expiresIn,
+		RetryAfterIn:      expiresIn,
 		RetryAttemptsLeft: retryAttemptsLeft,
 		IsValid:           false,
 	}, nil
 }
 
 func (ou *otpUseCase) ValidateOTP(ctx context.Context, code string) (res *dto.OtpDto, err error) {
+	ctx, span := tracer.Start(ctx, "OtpUseCase.ValidateOTP")
+	defer func() { tracing.End(span, err) }()
+
 	user, err := ou.userUseCase.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	event := domain.AccountEvent{Type: domain.AccountOtpValidated, UserID: user.ID}
 	otpIsValid, err := ou.otpService.ValidateOTP(ctx, user.Email, &code)
 	if err != nil {
+		ou.metrics.OtpValidated(false)
+		publishFailure(ctx, ou.events, event, failureReason(err, otpFailureReasons))
+		return nil, err
+	}
+
+	ou.metrics.OtpValidated(otpIsValid)
+	if !otpIsValid {
+		publishFailure(ctx, ou.events, event, domainError.ErrInvalidOTPCode.Error())
+	} else if err = ou.events.Publish(ctx, event); err != nil {
 		return nil, err
 	}
 
@@ -77,35 +135,71 @@ func (ou *otpUseCase) ValidateOTP(ctx context.Context, code string) (res *dto.Ot
 	}, nil
 }
 
-func (ou *otpUseCase) sendOTPEmailAsync(email string, code string) {
-	go func() {
-		if err := ou.sendOTPEmail(email, code); err != nil {
-			log.Printf("failed to send OTP email to %s: %v", email, err)
-		}
-	}()
-}
+// publishLocked publishes AccountLocked the first time OTPs are refused, it never changes the error returned
+func (ou *otpUseCase) publishLocked(ctx context.Context, userID int64, email string) {
+	lockedUntil, isNew, err := ou.otpService.LockOTP(ctx, email)
+	if err != nil {
+		ou.logger.WarnContext(ctx, "failed to lock OTPs", "error", err)
+		return
+	}
+
+	if !isNew {
+		return
+	}
 
-func (ou *otpUseCase) sendOTPEmail(email string, code string) (err error) {
-	_, filename, _, _ := runtime.Caller(0)
-	templatePath := filepath.Join(filepath.Dir(filename), "templates", "otp_email_template.html")
+	err = ou.events.Publish(ctx, domain.AccountEvent{
+		Type:     domain.AccountLocked,
+		UserID:   userID,
+		Metadata: map[string]string{domain.EventMetadataLockedUntil: lockedUntil.UTC().Format(time.RFC3339)},
+	})
+	if err != nil {
+		ou.logger.WarnContext(ctx, "failed to publish account lock", "error", err)
+	}
+}
 
-	tmpl, err := template.ParseFiles(templatePath)
+// getPreferences never fails the OTP flow, defaults are used when preferences can't be read
+func (ou *otpUseCase) getPreferences(ctx context.Context, userID int64) *userDto.PreferencesDto {
+	preferences, err := ou.preferenceUseCase.GetUserPreferences(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("failed to parse email template: %v", err)
+		ou.logger.WarnContext(ctx, "failed to get preferences, using the defaults", "error", err)
+		return nil
+	}
+
+	return preferences
+}
+
+func (ou *otpUseCase) resolveOtpMethod(preferences *userDto.PreferencesDto) enums.OtpMethod {
+	if preferences != nil && preferences.OtpChannel == enums.SMS.String() {
+		return enums.SMS
+	}
+
+	return enums.Email
+}
+
+// resolveLocale prefers the user's locale and falls back to the request's Accept-Language
+func (ou *otpUseCase) resolveLocale(ctx context.Context, preferences *userDto.PreferencesDto) string {
+	var locale string
+	if preferences != nil {
+		locale = preferences.Locale
 	}
 
+	return ou.templateService.ResolveLocale(locale, infraContext.GetAcceptLanguageFromContext(ctx))
+}
+
+// sendOTPEmail queues the email in the outbox, it joins the caller's transaction if there is one
+func (ou *otpUseCase) sendOTPEmail(ctx context.Context, email string, code string, locale string) (err error) {
 	data := make(map[string]interface{})
-	data["otp"] = code
-	data["exp"] = 3
+	data["code"] = code
+	data["expiresInMinutes"] = int(math.Ceil(ou.config.Current().OTP.GetExpiration().Minutes()))
 
-	var body bytes.Buffer
-	if err := tmpl.Execute(&body, data); err != nil {
-		return fmt.Errorf("failed to render email template: %v", err)
+	body, err := ou.templateService.Render(smtp.OtpEmail, locale, data)
+	if err != nil {
+		return err
 	}
 
-	err = ou.smtpService.SendHTML(email, "Your Verification Code", body.String())
+	err = ou.emailQueue.Enqueue(ctx, email, body)
 	if err != nil {
-		return fmt.Errorf("failed to send OTP email: %v", err)
+		return fmt.Errorf("failed to send OTP email: %w", err)
 	}
 
 	return nil
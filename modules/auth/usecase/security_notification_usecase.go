@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/infrastructure/smtp"
+	"github.com/winartodev/apollo-be/infrastructure/tracing"
+	"github.com/winartodev/apollo-be/internal/domain"
+	"github.com/winartodev/apollo-be/modules/auth/domain/service"
+	userUseCase "github.com/winartodev/apollo-be/modules/user/usecase"
+)
+
+// securityEmailTemplates are the notified account events and their email templates. Email and 2FA changes are not
+// listed because users can't change their email or enable 2FA yet, their events and templates come with those features.
+var securityEmailTemplates = map[domain.AccountEventType]string{
+	domain.AccountSignedIn:        smtp.NewDeviceSignInEmail,
+	domain.AccountPasswordChanged: smtp.PasswordChangedEmail,
+	domain.AccountLocked:          smtp.AccountLockedEmail,
+}
+
+// SecurityNotificationUseCase emails users about sensitive account events, Handle subscribes to the event bus.
+// Only sign-ins from an IP or user agent the user hasn't signed in from before are notified.
+// Handle logs its failures and always returns nil, a notification never fails the change that caused it.
+type SecurityNotificationUseCase interface {
+	Handle(ctx context.Context, event domain.AccountEvent) error
+}
+
+type securityNotificationUseCase struct {
+	emailQueue        smtp.EmailQueue
+	templateService   smtp.TemplateService
+	config            *config.Watcher
+	securityService   service.SecurityService
+	userUseCase       userUseCase.UserUseCase
+	preferenceUseCase userUseCase.PreferenceUseCase
+	transactor        domain.Transactor
+	logger            *slog.Logger
+}
+
+func NewSecurityNotificationUseCase(securityService service.SecurityService, userUseCase userUseCase.UserUseCase, preferenceUseCase userUseCase.PreferenceUseCase, emailQueue smtp.EmailQueue, templateService smtp.TemplateService, configWatcher *config.Watcher, transactor domain.Transactor, appLogger *logger.Logger) SecurityNotificationUseCase {
+	return &securityNotificationUseCase{
+		transactor:        transactor,
+		emailQueue:        emailQueue,
+		templateService:   templateService,
+		config:            configWatcher,
+		securityService:   securityService,
+		userUseCase:       userUseCase,
+		preferenceUseCase: preferenceUseCase,
+		logger:            appLogger.Named("auth"),
+	}
+}
+
+func (su *securityNotificationUseCase) Handle(ctx context.Context, event domain.AccountEvent) error {
+	template, ok := securityEmailTemplates[event.Type]
+	if !ok || event.Result == domain.EventFailed {
+		return nil
+	}
+
+	// The savepoint keeps a failed insert from aborting the transaction of the change
+	err := su.transactor.WithSavepoint(ctx, func(ctx context.Context) error {
+		return su.notify(ctx, event, template)
+	})
+	if err != nil {
+		su.logger.ErrorContext(ctx, "failed to send security notification", "event", event.Type, "user_id", event.UserID, "error", err)
+	}
+
+	return nil
+}
+
+func (su *securityNotificationUseCase) notify(ctx context.Context, event domain.AccountEvent, template string) (err error) {
+	ctx, span := tracer.Start(ctx, "SecurityNotificationUseCase.Handle")
+	defer func() { tracing.End(span, err) }()
+
+	// Sign-ins are recorded even when notifications are disabled, so enabling them doesn't flag every device as new
+	if event.Type == domain.AccountSignedIn {
+		isNew, err := su.securityService.IsNewDevice(ctx, event.UserID, event.IP, event.UserAgent)
+		if err != nil || !isNew {
+			return err
+		}
+	}
+
+	if su.config.Current().SecurityNotifications.Disabled {
+		return nil
+	}
+
+	user, err := su.userUseCase.GetCurrentUser(context.WithValue(ctx, infraContext.UserIdKey, event.UserID))
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"username": user.Username,
+		"time":     event.OccurredAt.UTC().Format(time.RFC1123),
+	}
+
+	switch event.Type {
+	case domain.AccountSignedIn:
+		data["ip"] = event.IP
+		data["device"] = event.UserAgent
+		if event.UserAgent == "" {
+			data["device"] = "-"
+		}
+	case domain.AccountLocked:
+		data["until"] = event.Metadata[domain.EventMetadataLockedUntil]
+		if until, err := time.Parse(time.RFC3339, event.Metadata[domain.EventMetadataLockedUntil]); err == nil {
+			data["until"] = until.UTC().Format(time.RFC1123)
+		}
+	}
+
+	if data["reportLink"], err = su.buildReportLink(ctx, event.UserID); err != nil {
+		return err
+	}
+
+	body, err := su.templateService.Render(template, su.resolveLocale(ctx, event.UserID), data)
+	if err != nil {
+		return err
+	}
+
+	err = su.emailQueue.Enqueue(ctx, user.Email, body)
+	if err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+// buildReportLink returns the "this wasn't me" link, empty when no report URL is configured
+func (su *securityNotificationUseCase) buildReportLink(ctx context.Context, userID int64) (string, error) {
+	reportURL := su.config.Current().SecurityNotifications.ReportURL
+	if reportURL == "" {
+		return "", nil
+	}
+
+	token, err := su.securityService.CreateReportToken(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(reportURL, token), nil
+}
+
+// resolveLocale prefers the user's locale, a notification is never dropped because preferences can't be read
+func (su *securityNotificationUseCase) resolveLocale(ctx context.Context, userID int64) string {
+	locale := ""
+	preferences, err := su.preferenceUseCase.GetUserPreferences(ctx, userID)
+	if err != nil {
+		su.logger.WarnContext(ctx, "failed to get preferences, using the defaults", "error", err)
+	} else if preferences != nil {
+		locale = preferences.Locale
+	}
+
+	return su.templateService.ResolveLocale(locale, infraContext.GetAcceptLanguageFromContext(ctx))
+}
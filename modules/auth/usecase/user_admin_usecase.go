@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/winartodev/apollo-be/infrastructure/phone"
+	"github.com/winartodev/apollo-be/internal/domain"
+	domainEntity "github.com/winartodev/apollo-be/internal/domain/entities"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/auth/domain/service"
+	"github.com/winartodev/apollo-be/modules/auth/usecase/dto"
+	userUseCase "github.com/winartodev/apollo-be/modules/user/usecase"
+)
+
+// UserAdminUseCase manages users on behalf of an operator, e.g. from the command line.
+// It skips the registration mode, invitations and OTP verification of the sign-up flow.
+type UserAdminUseCase interface {
+	CreateUser(ctx context.Context, data dto.CreateUserDto) (res *dto.UserAdminDto, err error)
+	DeactivateUser(ctx context.Context, identifier string) (err error)
+	ResetPassword(ctx context.Context, identifier string, password string) (err error)
+}
+
+type userAdminUseCase struct {
+	authService  service.AuthService
+	userUseCase  userUseCase.UserUseCase
+	userStatus   domain.UserStatusService
+	phoneService phone.PhoneNumberService
+	transactor   domain.Transactor
+	events       domain.EventPublisher
+}
+
+func NewUserAdminUseCase(authService service.AuthService, userUseCase userUseCase.UserUseCase, userStatus domain.UserStatusService, phoneService phone.PhoneNumberService, transactor domain.Transactor, events domain.EventPublisher) (UserAdminUseCase, error) {
+	return &userAdminUseCase{
+		authService:  authService,
+		userUseCase:  userUseCase,
+		userStatus:   userStatus,
+		phoneService: phoneService,
+		transactor:   transactor,
+		events:       events,
+	}, nil
+}
+
+func (uc *userAdminUseCase) CreateUser(ctx context.Context, data dto.CreateUserDto) (res *dto.UserAdminDto, err error) {
+	phoneNumber := ""
+	if data.PhoneNumber != "" {
+		phoneNumber, err = uc.phoneService.Normalize(data.PhoneNumber, "")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domainError.ErrInvalidPhoneNumber, err)
+		}
+	}
+
+	sharedUser := domainEntity.SharedUser{
+		Username:    data.Username,
+		Email:       data.Email,
+		Password:    data.Password,
+		PhoneNumber: phoneNumber,
+		FirstName:   data.FirstName,
+		LastName:    data.LastName,
+		IsAdmin:     data.IsAdmin,
+	}
+
+	user, err := uc.userUseCase.CheckUserIfExists(ctx, sharedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if user != nil {
+		return nil, domainError.ErrUserAlreadyExists
+	}
+
+	var newUser *domainEntity.SharedUser
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		newUser, err = uc.authService.CreateNewUser(ctx, sharedUser)
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountCreated, UserID: newUser.ID, Actor: domain.ActorOperator})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UserAdminDto{
+		ID:       newUser.ID,
+		Username: newUser.Username,
+		Email:    newUser.Email,
+		IsAdmin:  newUser.IsAdmin,
+	}, nil
+}
+
+func (uc *userAdminUseCase) DeactivateUser(ctx context.Context, identifier string) (err error) {
+	user, err := uc.authService.GetUserByIdentifier(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := uc.authService.DeactivateUser(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountDeactivated, UserID: user.ID, Actor: domain.ActorOperator})
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.userStatus.InvalidateUserStatus(ctx, user.ID)
+}
+
+// ResetPassword sets a new password and signs the user out everywhere, revoking the refresh token and every
+// access token already issued. The user is notified by email.
+func (uc *userAdminUseCase) ResetPassword(ctx context.Context, identifier string, password string) (err error) {
+	user, err := uc.authService.GetUserByIdentifier(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := uc.authService.UpdatePassword(ctx, user.ID, password)
+		if err != nil {
+			return err
+		}
+
+		err = uc.authService.RevokeTokens(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return uc.events.Publish(ctx, domain.AccountEvent{Type: domain.AccountPasswordChanged, UserID: user.ID, Actor: domain.ActorOperator})
+	})
+	if err != nil {
+		return err
+	}
+
+	// The cached status still holds the old token version until it is dropped
+	return uc.userStatus.InvalidateUserStatus(ctx, user.ID)
+}
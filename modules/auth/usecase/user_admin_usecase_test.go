@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+)
+
+func TestResetPasswordEndsSessionsButNotTheAccount(t *testing.T) {
+	at := newAuthTest(t)
+	before := at.signIn(t, testPassword)
+
+	userAdmin, err := NewUserAdminUseCase(at.authService, &fakeUserUseCase{store: at.store}, at.userStatus, nil, fakeTransactor{}, fakeEvents{})
+	if err != nil {
+		t.Fatalf("NewUserAdminUseCase() error = %v", err)
+	}
+
+	const newPassword = "NewSecret456!"
+	if err := userAdmin.ResetPassword(context.Background(), testUsername, newPassword); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	if err := at.authorize(before.AccessToken); !errors.Is(err, domainError.ErrTokenRevoked) {
+		t.Errorf("authorizing a token issued before the reset error = %v, want %v", err, domainError.ErrTokenRevoked)
+	}
+
+	if _, err := at.auth.SignIn(context.Background(), signInDto(testPassword)); !errors.Is(err, domainError.ErrInvalidUsernameOrPassword) {
+		t.Errorf("SignIn() with the old password error = %v, want %v", err, domainError.ErrInvalidUsernameOrPassword)
+	}
+
+	after := at.signIn(t, newPassword)
+	if err := at.authorize(after.AccessToken); err != nil {
+		t.Errorf("authorizing a token issued after the reset error = %v, want nil", err)
+	}
+}
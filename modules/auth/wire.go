@@ -9,14 +9,24 @@ import (
 	"github.com/google/wire"
 	"github.com/redis/go-redis/v9"
 	config2 "github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/internal/domain"
 	"github.com/winartodev/apollo-be/modules/auth/delivery/http"
+	"github.com/winartodev/apollo-be/modules/auth/usecase"
 )
 
 func InitializeAuthAPI(
 	db *sql.DB,
 	redis *redis.Client,
+	jwtConfig *config2.Jwt,
 	smtpConfig *config2.SMTPConfig,
-	otp *config2.Otp,
+	configWatcher *config2.Watcher,
+	username *config2.Username,
+	countryConfig *config2.Country,
+	phoneConfig *config2.Phone,
+	metrics domain.Metrics,
+	appLogger *logger.Logger,
+	eventPublisher domain.EventPublisher,
 ) (*http.AuthHandler, error) {
 	wire.Build(moduleSet)
 	return &http.AuthHandler{}, nil
@@ -25,9 +35,53 @@ func InitializeAuthAPI(
 func InitializeOtpAPI(
 	db *sql.DB,
 	redis *redis.Client,
+	jwtConfig *config2.Jwt,
 	smtpConfig *config2.SMTPConfig,
-	otp *config2.Otp,
+	configWatcher *config2.Watcher,
+	metrics domain.Metrics,
+	appLogger *logger.Logger,
+	eventPublisher domain.EventPublisher,
 ) (*http.OtpHandler, error) {
 	wire.Build(moduleSet)
 	return &http.OtpHandler{}, nil
 }
+
+func InitializeInvitationAPI(
+	db *sql.DB,
+	redis *redis.Client,
+	jwtConfig *config2.Jwt,
+	smtpConfig *config2.SMTPConfig,
+	configWatcher *config2.Watcher,
+) (*http.InvitationHandler, error) {
+	wire.Build(moduleSet)
+	return &http.InvitationHandler{}, nil
+}
+
+func InitializeUserAdmin(
+	db *sql.DB,
+	redis *redis.Client,
+	phoneConfig *config2.Phone,
+	eventPublisher domain.EventPublisher,
+) (usecase.UserAdminUseCase, error) {
+	wire.Build(moduleSet)
+	return nil, nil
+}
+
+func InitializeSecurityNotifier(
+	db *sql.DB,
+	redis *redis.Client,
+	smtpConfig *config2.SMTPConfig,
+	configWatcher *config2.Watcher,
+	appLogger *logger.Logger,
+) (usecase.SecurityNotificationUseCase, error) {
+	wire.Build(moduleSet)
+	return nil, nil
+}
+
+func InitializeAPIKeyService(
+	db *sql.DB,
+	redis *redis.Client,
+) (domain.APIKeyService, error) {
+	wire.Build(moduleSet)
+	return nil, nil
+}
@@ -8,18 +8,24 @@ package auth
 
 import (
 	"database/sql"
-
 	"github.com/redis/go-redis/v9"
 	"github.com/winartodev/apollo-be/config"
 	"github.com/winartodev/apollo-be/infrastructure/auth"
 	"github.com/winartodev/apollo-be/infrastructure/database"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
 	"github.com/winartodev/apollo-be/infrastructure/middleware"
+	"github.com/winartodev/apollo-be/infrastructure/outbox"
+	"github.com/winartodev/apollo-be/infrastructure/phone"
 	redis2 "github.com/winartodev/apollo-be/infrastructure/redis"
 	"github.com/winartodev/apollo-be/infrastructure/smtp"
+	"github.com/winartodev/apollo-be/internal/domain"
 	"github.com/winartodev/apollo-be/modules/auth/delivery/http"
 	"github.com/winartodev/apollo-be/modules/auth/domain/service"
 	"github.com/winartodev/apollo-be/modules/auth/repository"
 	usecase2 "github.com/winartodev/apollo-be/modules/auth/usecase"
+	service3 "github.com/winartodev/apollo-be/modules/country/domain/service"
+	repository3 "github.com/winartodev/apollo-be/modules/country/repository"
+	usecase3 "github.com/winartodev/apollo-be/modules/country/usecase"
 	service2 "github.com/winartodev/apollo-be/modules/user/domain/service"
 	repository2 "github.com/winartodev/apollo-be/modules/user/repository"
 	"github.com/winartodev/apollo-be/modules/user/usecase"
@@ -27,7 +33,7 @@ import (
 
 // Injectors from wire.go:
 
-func InitializeAuthAPI(db *sql.DB, redis3 *redis.Client, smtpConfig *config.SMTPConfig, otp *config.Otp) (*http.AuthHandler, error) {
+func InitializeAuthAPI(db *sql.DB, redis3 *redis.Client, jwtConfig *config.Jwt, smtpConfig *config.SMTPConfig, configWatcher *config.Watcher, username *config.Username, countryConfig *config.Country, phoneConfig *config.Phone, metrics domain.Metrics, appLogger *logger.Logger, eventPublisher domain.EventPublisher) (*http.AuthHandler, error) {
 	databaseDatabase, err := database.NewDatabase(db)
 	if err != nil {
 		return nil, err
@@ -41,15 +47,35 @@ func InitializeAuthAPI(db *sql.DB, redis3 *redis.Client, smtpConfig *config.SMTP
 	if err != nil {
 		return nil, err
 	}
+	usernameSuggestionService, err := service.NewUsernameSuggestionService(authRepository, username)
+	if err != nil {
+		return nil, err
+	}
+	invitationRepository, err := repository.NewInvitationRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	invitationService, err := service.NewInvitationService(invitationRepository, configWatcher, databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
 	redisRedis, err := redis2.NewRedis(redis3)
 	if err != nil {
 		return nil, err
 	}
+	securityRepository, err := repository.NewSecurityRepository(databaseDatabase, redisRedis)
+	if err != nil {
+		return nil, err
+	}
+	securityService, err := service.NewSecurityService(securityRepository, configWatcher)
+	if err != nil {
+		return nil, err
+	}
 	otpRepository, err := repository.NewOtpRepository(redisRedis)
 	if err != nil {
 		return nil, err
 	}
-	otpService, err := service.NewOtpService(otpRepository)
+	otpService, err := service.NewOtpService(otpRepository, configWatcher)
 	if err != nil {
 		return nil, err
 	}
@@ -65,23 +91,52 @@ func InitializeAuthAPI(db *sql.DB, redis3 *redis.Client, smtpConfig *config.SMTP
 	if err != nil {
 		return nil, err
 	}
-	smtpService := smtp.NewSMTPService(smtpConfig)
-	otpUseCase := usecase2.NewOtpUseCase(otpService, userUseCase, smtpService, otp)
-	jwt, err := auth.NewJWT()
+	preferenceService, err := service2.NewPreferenceService(userRepository)
+	if err != nil {
+		return nil, err
+	}
+	preferenceUseCase, err := usecase.NewPreferenceUseCase(preferenceService)
+	if err != nil {
+		return nil, err
+	}
+	outboxOutbox := outbox.NewOutbox(databaseDatabase)
+	emailQueue := smtp.NewEmailQueue(outboxOutbox)
+	templateService := smtp.NewTemplateService(smtpConfig)
+	otpUseCase := usecase2.NewOtpUseCase(otpService, userUseCase, preferenceUseCase, emailQueue, templateService, configWatcher, metrics, eventPublisher, appLogger)
+	countryRepository, err := repository3.NewCountryRepository(redisRedis, countryConfig)
+	if err != nil {
+		return nil, err
+	}
+	countryLocalizationService, err := service3.NewCountryLocalizationService()
+	if err != nil {
+		return nil, err
+	}
+	countryService, err := service3.NewCountryService(countryRepository, countryLocalizationService, appLogger)
+	if err != nil {
+		return nil, err
+	}
+	countryUseCase, err := usecase3.NewCountryUseCase(countryService, countryLocalizationService)
+	if err != nil {
+		return nil, err
+	}
+	phoneNumberService := phone.NewPhoneNumberService(phoneConfig)
+	jwt, err := auth.NewJWT(jwtConfig)
 	if err != nil {
 		return nil, err
 	}
 	tokenService := auth.NewJwtTokenService(jwt)
-	authUseCase, err := usecase2.NewAuthUseCase(authService, otpUseCase, tokenService, userUseCase)
+	userStatusService := auth.NewUserStatusService(databaseDatabase, redisRedis)
+	authUseCase, err := usecase2.NewAuthUseCase(authService, usernameSuggestionService, invitationService, securityService, otpUseCase, countryUseCase, phoneNumberService, tokenService, userUseCase, userStatusService, metrics, databaseDatabase, eventPublisher, appLogger)
 	if err != nil {
 		return nil, err
 	}
-	middlewareMiddleware := middleware.NewMiddleware(tokenService)
+	apiKeyService := auth.NewAPIKeyService(databaseDatabase, redisRedis)
+	middlewareMiddleware := middleware.NewMiddleware(tokenService, userStatusService, apiKeyService)
 	authHandler := http.NewAuthHandler(authUseCase, middlewareMiddleware)
 	return authHandler, nil
 }
 
-func InitializeOtpAPI(db *sql.DB, redis3 *redis.Client, smtpConfig *config.SMTPConfig, otp *config.Otp) (*http.OtpHandler, error) {
+func InitializeOtpAPI(db *sql.DB, redis3 *redis.Client, jwtConfig *config.Jwt, smtpConfig *config.SMTPConfig, configWatcher *config.Watcher, metrics domain.Metrics, appLogger *logger.Logger, eventPublisher domain.EventPublisher) (*http.OtpHandler, error) {
 	redisRedis, err := redis2.NewRedis(redis3)
 	if err != nil {
 		return nil, err
@@ -90,7 +145,7 @@ func InitializeOtpAPI(db *sql.DB, redis3 *redis.Client, smtpConfig *config.SMTPC
 	if err != nil {
 		return nil, err
 	}
-	otpService, err := service.NewOtpService(otpRepository)
+	otpService, err := service.NewOtpService(otpRepository, configWatcher)
 	if err != nil {
 		return nil, err
 	}
@@ -110,14 +165,170 @@ func InitializeOtpAPI(db *sql.DB, redis3 *redis.Client, smtpConfig *config.SMTPC
 	if err != nil {
 		return nil, err
 	}
-	smtpService := smtp.NewSMTPService(smtpConfig)
-	otpUseCase := usecase2.NewOtpUseCase(otpService, userUseCase, smtpService, otp)
-	jwt, err := auth.NewJWT()
+	preferenceService, err := service2.NewPreferenceService(userRepository)
+	if err != nil {
+		return nil, err
+	}
+	preferenceUseCase, err := usecase.NewPreferenceUseCase(preferenceService)
+	if err != nil {
+		return nil, err
+	}
+	outboxOutbox := outbox.NewOutbox(databaseDatabase)
+	emailQueue := smtp.NewEmailQueue(outboxOutbox)
+	templateService := smtp.NewTemplateService(smtpConfig)
+	otpUseCase := usecase2.NewOtpUseCase(otpService, userUseCase, preferenceUseCase, emailQueue, templateService, configWatcher, metrics, eventPublisher, appLogger)
+	jwt, err := auth.NewJWT(jwtConfig)
 	if err != nil {
 		return nil, err
 	}
 	tokenService := auth.NewJwtTokenService(jwt)
-	middlewareMiddleware := middleware.NewMiddleware(tokenService)
+	userStatusService := auth.NewUserStatusService(databaseDatabase, redisRedis)
+	apiKeyService := auth.NewAPIKeyService(databaseDatabase, redisRedis)
+	middlewareMiddleware := middleware.NewMiddleware(tokenService, userStatusService, apiKeyService)
 	otpHandler := http.NewOtpHandler(otpUseCase, userUseCase, middlewareMiddleware)
 	return otpHandler, nil
 }
+
+func InitializeInvitationAPI(db *sql.DB, redis3 *redis.Client, jwtConfig *config.Jwt, smtpConfig *config.SMTPConfig, configWatcher *config.Watcher) (*http.InvitationHandler, error) {
+	databaseDatabase, err := database.NewDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	invitationRepository, err := repository.NewInvitationRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	invitationService, err := service.NewInvitationService(invitationRepository, configWatcher, databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	userRepository, err := repository2.NewUserRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	userService, err := service2.NewUserService(userRepository)
+	if err != nil {
+		return nil, err
+	}
+	userUseCase, err := usecase.NewUserUseCase(userService)
+	if err != nil {
+		return nil, err
+	}
+	outboxOutbox := outbox.NewOutbox(databaseDatabase)
+	emailQueue := smtp.NewEmailQueue(outboxOutbox)
+	templateService := smtp.NewTemplateService(smtpConfig)
+	invitationUseCase, err := usecase2.NewInvitationUseCase(invitationService, userUseCase, emailQueue, templateService, configWatcher, databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	jwt, err := auth.NewJWT(jwtConfig)
+	if err != nil {
+		return nil, err
+	}
+	tokenService := auth.NewJwtTokenService(jwt)
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	userStatusService := auth.NewUserStatusService(databaseDatabase, redisRedis)
+	apiKeyService := auth.NewAPIKeyService(databaseDatabase, redisRedis)
+	middlewareMiddleware := middleware.NewMiddleware(tokenService, userStatusService, apiKeyService)
+	invitationHandler := http.NewInvitationHandler(invitationUseCase, middlewareMiddleware)
+	return invitationHandler, nil
+}
+
+func InitializeUserAdmin(db *sql.DB, redis3 *redis.Client, phoneConfig *config.Phone, eventPublisher domain.EventPublisher) (usecase2.UserAdminUseCase, error) {
+	databaseDatabase, err := database.NewDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	authRepository, err := repository.NewAuthRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	passwordService := auth.NewBcryptPasswordService()
+	authService, err := service.NewAuthService(authRepository, passwordService)
+	if err != nil {
+		return nil, err
+	}
+	userRepository, err := repository2.NewUserRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	userService, err := service2.NewUserService(userRepository)
+	if err != nil {
+		return nil, err
+	}
+	userUseCase, err := usecase.NewUserUseCase(userService)
+	if err != nil {
+		return nil, err
+	}
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	userStatusService := auth.NewUserStatusService(databaseDatabase, redisRedis)
+	phoneNumberService := phone.NewPhoneNumberService(phoneConfig)
+	userAdminUseCase, err := usecase2.NewUserAdminUseCase(authService, userUseCase, userStatusService, phoneNumberService, databaseDatabase, eventPublisher)
+	if err != nil {
+		return nil, err
+	}
+	return userAdminUseCase, nil
+}
+
+func InitializeSecurityNotifier(db *sql.DB, redis3 *redis.Client, smtpConfig *config.SMTPConfig, configWatcher *config.Watcher, appLogger *logger.Logger) (usecase2.SecurityNotificationUseCase, error) {
+	databaseDatabase, err := database.NewDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	securityRepository, err := repository.NewSecurityRepository(databaseDatabase, redisRedis)
+	if err != nil {
+		return nil, err
+	}
+	securityService, err := service.NewSecurityService(securityRepository, configWatcher)
+	if err != nil {
+		return nil, err
+	}
+	userRepository, err := repository2.NewUserRepository(databaseDatabase)
+	if err != nil {
+		return nil, err
+	}
+	userService, err := service2.NewUserService(userRepository)
+	if err != nil {
+		return nil, err
+	}
+	userUseCase, err := usecase.NewUserUseCase(userService)
+	if err != nil {
+		return nil, err
+	}
+	preferenceService, err := service2.NewPreferenceService(userRepository)
+	if err != nil {
+		return nil, err
+	}
+	preferenceUseCase, err := usecase.NewPreferenceUseCase(preferenceService)
+	if err != nil {
+		return nil, err
+	}
+	outboxOutbox := outbox.NewOutbox(databaseDatabase)
+	emailQueue := smtp.NewEmailQueue(outboxOutbox)
+	templateService := smtp.NewTemplateService(smtpConfig)
+	securityNotificationUseCase := usecase2.NewSecurityNotificationUseCase(securityService, userUseCase, preferenceUseCase, emailQueue, templateService, configWatcher, databaseDatabase, appLogger)
+	return securityNotificationUseCase, nil
+}
+
+func InitializeAPIKeyService(db *sql.DB, redis3 *redis.Client) (domain.APIKeyService, error) {
+	databaseDatabase, err := database.NewDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	apiKeyService := auth.NewAPIKeyService(databaseDatabase, redisRedis)
+	return apiKeyService, nil
+}
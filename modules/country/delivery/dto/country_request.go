@@ -0,0 +1,15 @@
+package dto
+
+// CountryQueryRequest represents the filters of the country list
+type CountryQueryRequest struct {
+	Region      string `query:"region"`
+	CallingCode string `query:"calling_code"`
+	Search      string `query:"q"`
+	Fields      string `query:"fields"`
+}
+
+// CountryLookupRequest represents a single country lookup
+type CountryLookupRequest struct {
+	Code   string `param:"code"`
+	Fields string `query:"fields"`
+}
@@ -1,13 +1,112 @@
 package dto
 
+import (
+	"encoding/json"
+	"strings"
+)
+
+// CountryResponse represents a country
+// swagger:model CountryResponse
 type CountryResponse struct {
-	Name         string `json:"name"`
-	Code         string `json:"alpha_code"`
-	Flags        Flag   `json:"flags"`
+	// Country name in the language selected by Accept-Language
+	// example: Indonesia
+	Name string `json:"name"`
+
+	// ISO 3166-1 alpha-3 code, kept for backward compatibility
+	// example: IDN
+	Code string `json:"alpha_code"`
+
+	// ISO 3166-1 alpha-2 code
+	// example: ID
+	Alpha2 string `json:"alpha2"`
+
+	// ISO 3166-1 alpha-3 code
+	// example: IDN
+	Alpha3 string `json:"alpha3"`
+
+	// ISO 3166-1 numeric code
+	// example: 360
+	Numeric string `json:"numeric"`
+
+	// Region the country belongs to
+	// example: Asia
+	Region string `json:"region"`
+
+	Flags Flag `json:"flags"`
+
+	// First calling code
+	// example: 62
 	CallingCodes string `json:"calling_code"`
+
+	// Every calling code
+	// example: ["62"]
+	CallingCodeList []string `json:"calling_codes"`
 }
 
+// countryFields lists the JSON fields that can be selected
+var countryFields = countryResponseFields()
+
 type Flag struct {
 	Png string `json:"png"`
 	Svg string `json:"svg"`
 }
+
+// ParseCountryFields splits a comma separated fields parameter and reports the first unknown field
+func ParseCountryFields(fields string) (res []string, invalid string) {
+	if strings.TrimSpace(fields) == "" {
+		return nil, ""
+	}
+
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		if _, ok := countryFields[field]; !ok {
+			return nil, field
+		}
+
+		res = append(res, field)
+	}
+
+	return res, ""
+}
+
+// SelectFields keeps only the requested JSON fields, every field is returned when none are requested
+func (r CountryResponse) SelectFields(fields []string) interface{} {
+	if len(fields) == 0 {
+		return r
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return r
+	}
+
+	all := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &all); err != nil {
+		return r
+	}
+
+	res := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		res[field] = all[field]
+	}
+
+	return res
+}
+
+func countryResponseFields() map[string]struct{} {
+	data, _ := json.Marshal(CountryResponse{})
+
+	fields := make(map[string]json.RawMessage)
+	_ = json.Unmarshal(data, &fields)
+
+	res := make(map[string]struct{}, len(fields))
+	for field := range fields {
+		res[field] = struct{}{}
+	}
+
+	return res
+}
@@ -1,67 +1,125 @@
 package http
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/winartodev/apollo-be/helper"
 	"github.com/winartodev/apollo-be/infrastructure/http/response"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
 	"github.com/winartodev/apollo-be/modules/country/delivery/dto"
+	"github.com/winartodev/apollo-be/modules/country/usecase"
+	useCaseDto "github.com/winartodev/apollo-be/modules/country/usecase/dto"
 )
 
 const (
-	countriesApiURL = "https://www.apicountries.com/countries"
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+
+	errorInvalidCountryField = "%w: %s"
 )
 
 type CountryHandler struct {
+	countryUseCase usecase.CountryUseCase
 }
 
-func NewCountryHandler() *CountryHandler {
-	return &CountryHandler{}
+func NewCountryHandler(countryUseCase usecase.CountryUseCase) *CountryHandler {
+	return &CountryHandler{
+		countryUseCase: countryUseCase,
+	}
 }
 
+// Countries godoc
+//
+//	@Summary		List countries
+//	@Description	List countries sorted by name, names are localized using Accept-Language
+//	@Tags			Country
+//	@Produce		json
+//	@Param			Accept-Language	header		string											false	"Preferred languages"	example(id, en;q=0.8)
+//	@Param			region			query		string											false	"Region, e.g. asia or north_america"
+//	@Param			calling_code	query		string											false	"Calling code prefix, e.g. 62 or +1"
+//	@Param			q				query		string											false	"Name prefix"
+//	@Param			fields			query		string											false	"Comma separated fields to return, e.g. name,alpha2"
+//	@Success		200				{object}	response.Response{data=[]dto.CountryResponse}	"Countries"
+//	@Failure		400				{object}	response.ErrorResponse							"Invalid field"
+//	@Failure		500				{object}	response.ErrorResponse							"Internal server error"
+//	@Router			/countries [get]
 func (h *CountryHandler) Countries(e echo.Context) error {
-	req, err := http.NewRequest("GET", countriesApiURL, nil)
-	if err != nil {
-		return response.FailedResponse(e, http.StatusBadRequest, err)
+	var req dto.CountryQueryRequest
+
+	if err := e.Bind(&req); err != nil {
+		return response.FailedResponse(e, http.StatusBadRequest, fmt.Errorf(response.ErrInvalidRequestPayload, err))
 	}
 
-	client := &http.Client{}
+	fields, invalid := dto.ParseCountryFields(req.Fields)
+	if invalid != "" {
+		return response.FailedResponse(e, http.StatusBadRequest, fmt.Errorf(errorInvalidCountryField, domainError.ErrInvalidCountryField, invalid))
+	}
 
-	resp, err := client.Do(req)
+	ctx := e.Request().Context()
+	acceptLanguage := e.Request().Header.Get(headerAcceptLanguage)
+	res, err := h.countryUseCase.GetCountries(ctx, useCaseDto.NewCountryFilterDto(req, acceptLanguage))
 	if err != nil {
-		return response.FailedResponse(e, http.StatusBadRequest, err)
+		return response.FailedResponse(e, http.StatusInternalServerError, err)
+	}
+
+	data := make([]interface{}, len(res.Countries))
+	for i := range res.Countries {
+		data[i] = res.Countries[i].ToResponse().SelectFields(fields)
 	}
 
-	defer resp.Body.Close()
+	setLanguageHeaders(e, res.Language)
+
+	return response.SuccessResponse(e, http.StatusOK, "", data, nil)
+}
 
-	var apiResponse []struct {
-		Name         string   `json:"name"`
-		Alpha3Code   string   `json:"alpha3Code"`
-		Flags        dto.Flag `json:"flags"`
-		CallingCodes []string `json:"callingCodes"`
+// Country godoc
+//
+//	@Summary		Get country
+//	@Description	Get a country by its ISO 3166-1 alpha-2, alpha-3 or numeric code, the name is localized using Accept-Language
+//	@Tags			Country
+//	@Produce		json
+//	@Param			Accept-Language	header		string										false	"Preferred languages"	example(id, en;q=0.8)
+//	@Param			code			path		string										true	"Alpha-2, alpha-3 or numeric code, e.g. ID, IDN or 360"
+//	@Param			fields			query		string										false	"Comma separated fields to return, e.g. name,alpha2"
+//	@Success		200				{object}	response.Response{data=dto.CountryResponse}	"Country"
+//	@Failure		400				{object}	response.ErrorResponse						"Invalid code or field"
+//	@Failure		404				{object}	response.ErrorResponse						"Country not found"
+//	@Failure		500				{object}	response.ErrorResponse						"Internal server error"
+//	@Router			/countries/{code} [get]
+func (h *CountryHandler) Country(e echo.Context) error {
+	var req dto.CountryLookupRequest
+
+	if err := e.Bind(&req); err != nil {
+		return response.FailedResponse(e, http.StatusBadRequest, fmt.Errorf(response.ErrInvalidRequestPayload, err))
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return response.FailedResponse(e, http.StatusBadRequest, err)
+	fields, invalid := dto.ParseCountryFields(req.Fields)
+	if invalid != "" {
+		return response.FailedResponse(e, http.StatusBadRequest, fmt.Errorf(errorInvalidCountryField, domainError.ErrInvalidCountryField, invalid))
 	}
 
-	data := make([]dto.CountryResponse, len(apiResponse))
-	for i, country := range apiResponse {
-		data[i] = dto.CountryResponse{
-			Name:         country.Name,
-			Code:         country.Alpha3Code,
-			Flags:        country.Flags,
-			CallingCodes: helper.GetFirstElement(country.CallingCodes),
-		}
+	ctx := e.Request().Context()
+	acceptLanguage := e.Request().Header.Get(headerAcceptLanguage)
+	res, err := h.countryUseCase.GetCountry(ctx, req.Code, acceptLanguage)
+	if err != nil {
+		return response.FailedResponse(e, http.StatusInternalServerError, err)
 	}
 
-	return response.SuccessResponse(e, http.StatusOK, "", data, nil)
+	setLanguageHeaders(e, res.Language)
+
+	return response.SuccessResponse(e, http.StatusOK, "", res.ToResponse().SelectFields(fields), nil)
 }
 
 func (h *CountryHandler) RegisterRoutes(api *echo.Group) error {
 	api.GET("/countries", h.Countries)
+	api.GET("/countries/:code", h.Country)
 
 	return nil
 }
+
+// setLanguageHeaders tells clients and caches which language the names are in
+func setLanguageHeaders(e echo.Context, language string) {
+	e.Response().Header().Set(headerContentLanguage, language)
+	e.Response().Header().Add(echo.HeaderVary, headerAcceptLanguage)
+}
@@ -0,0 +1,58 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	"github.com/winartodev/apollo-be/modules/country/usecase"
+)
+
+type CountryRefreshJob struct {
+	countryUseCase usecase.CountryUseCase
+	interval       time.Duration
+	timeout        time.Duration
+	logger         *slog.Logger
+}
+
+func NewCountryRefreshJob(countryUseCase usecase.CountryUseCase, countryConfig *config.Country, appLogger *logger.Logger) *CountryRefreshJob {
+	return &CountryRefreshJob{
+		countryUseCase: countryUseCase,
+		logger:         appLogger.Named("country"),
+		interval:       countryConfig.Refresh.GetInterval(),
+		timeout:        countryConfig.Refresh.GetTimeout(),
+	}
+}
+
+// Start syncs the country dataset right away and then on every interval until the context is done
+func (j *CountryRefreshJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *CountryRefreshJob) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	updated, err := j.countryUseCase.SyncCountries(ctx)
+	if err != nil {
+		j.logger.ErrorContext(ctx, "country refresh failed", "error", err)
+		return
+	}
+
+	if updated {
+		j.logger.InfoContext(ctx, "country dataset refreshed")
+	}
+}
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/winartodev/apollo-be/modules/country/usecase/dto"
+)
+
+// Country is a single ISO 3166-1 entry of the country dataset
+type Country struct {
+	Name         string   `json:"name"`
+	Alpha2       string   `json:"alpha2"`
+	Alpha3       string   `json:"alpha3"`
+	Numeric      string   `json:"numeric"`
+	CallingCodes []string `json:"calling_codes"`
+	Region       string   `json:"region"`
+}
+
+func (c *Country) ToUseCaseData() dto.CountryDto {
+	return dto.CountryDto{
+		Name:         c.Name,
+		Alpha2:       c.Alpha2,
+		Alpha3:       c.Alpha3,
+		Numeric:      c.Numeric,
+		CallingCodes: c.CallingCodes,
+		Region:       c.Region,
+	}
+}
+
+// CountryDataset is a synced copy of the dataset shared between instances through Redis
+type CountryDataset struct {
+	ETag      string    `json:"etag"`
+	SyncedAt  int64     `json:"synced_at"`
+	Countries []Country `json:"countries"`
+}
+
+// CountryFilter narrows down the country list, empty fields are ignored
+type CountryFilter struct {
+	Region      string
+	CallingCode string
+	Search      string
+	Language    language.Tag
+}
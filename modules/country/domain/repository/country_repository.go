@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/winartodev/apollo-be/modules/country/domain/entities"
+)
+
+type CountryRepository interface {
+	GetEmbeddedCountries() (res []entities.Country, err error)
+	GetCountriesRedis(ctx context.Context) (res *entities.CountryDataset, err error)
+	GetCountriesETagRedis(ctx context.Context) (res string, err error)
+	SetCountriesRedis(ctx context.Context, data entities.CountryDataset) (err error)
+	FetchCountriesUpstream(ctx context.Context, etag string) (res *entities.CountryDataset, err error)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/winartodev/apollo-be/modules/country/domain/entities"
+)
+
+type CountryLocalizationService interface {
+	ResolveLanguage(acceptLanguage string) language.Tag
+	LocalizedName(country entities.Country, tag language.Tag) string
+}
+
+type countryLocalizationService struct {
+	matcher language.Matcher
+	tags    []language.Tag
+}
+
+func NewCountryLocalizationService() (CountryLocalizationService, error) {
+	// English comes first so it is picked when nothing in Accept-Language is supported
+	tags := append([]language.Tag{language.English}, display.Supported.Tags()...)
+
+	return &countryLocalizationService{
+		matcher: language.NewMatcher(tags),
+		tags:    tags,
+	}, nil
+}
+
+// ResolveLanguage picks the best supported language for an Accept-Language header, defaulting to English
+func (cls *countryLocalizationService) ResolveLanguage(acceptLanguage string) language.Tag {
+	if acceptLanguage == "" {
+		return language.English
+	}
+
+	preferred, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferred) == 0 {
+		return language.English
+	}
+
+	_, index, confidence := cls.matcher.Match(preferred...)
+	if confidence == language.No {
+		return language.English
+	}
+
+	return cls.tags[index]
+}
+
+// LocalizedName returns the CLDR name of the country in the given language.
+// English keeps the dataset name, which follows the ISO 3166 short names.
+func (cls *countryLocalizationService) LocalizedName(country entities.Country, tag language.Tag) string {
+	if base, _ := tag.Base(); base.String() == "en" {
+		return country.Name
+	}
+
+	region, err := language.ParseRegion(country.Alpha2)
+	if err != nil {
+		return country.Name
+	}
+
+	namer := display.Regions(tag)
+	if namer == nil {
+		return country.Name
+	}
+
+	if name := namer.Name(region); name != "" {
+		return name
+	}
+
+	return country.Name
+}
+
+// foldName lower cases the name and strips diacritics so searches for "cote" match "Côte d'Ivoire"
+func foldName(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, name)
+	if err != nil {
+		folded = name
+	}
+
+	return strings.ToLower(folded)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/country/domain/entities"
+	"github.com/winartodev/apollo-be/modules/country/domain/repository"
+)
+
+const (
+	// countryCheckInterval controls how often the in-memory dataset is compared with the synced copy in Redis
+	countryCheckInterval = time.Minute
+	countryCheckTimeout  = 500 * time.Millisecond
+
+	numericCodeLength = 3
+
+	errorSyncCountries = "%w: %v"
+)
+
+type CountryService interface {
+	GetCountries(ctx context.Context) (res []entities.Country, err error)
+	GetCountry(ctx context.Context, code string) (res *entities.Country, err error)
+	FindCountries(ctx context.Context, filter entities.CountryFilter) (res []entities.Country, err error)
+	SyncCountries(ctx context.Context) (updated bool, err error)
+}
+
+// countrySnapshot is an immutable dataset together with its lookup indexes
+type countrySnapshot struct {
+	etag      string
+	countries []entities.Country
+	byAlpha2  map[string]int
+	byAlpha3  map[string]int
+	byNumeric map[string]int
+}
+
+type countryService struct {
+	countryRepo         repository.CountryRepository
+	localizationService CountryLocalizationService
+	logger              *slog.Logger
+
+	mu        sync.RWMutex
+	snapshot  *countrySnapshot
+	checkedAt time.Time
+}
+
+func NewCountryService(countryRepo repository.CountryRepository, localizationService CountryLocalizationService, appLogger *logger.Logger) (CountryService, error) {
+	countries, err := countryRepo.GetEmbeddedCountries()
+	if err != nil {
+		return nil, err
+	}
+
+	return &countryService{
+		countryRepo:         countryRepo,
+		localizationService: localizationService,
+		logger:              appLogger.Named("country"),
+		snapshot:            newCountrySnapshot("", countries),
+	}, nil
+}
+
+func (cs *countryService) GetCountries(ctx context.Context) (res []entities.Country, err error) {
+	return cs.getSnapshot(ctx).countries, nil
+}
+
+// GetCountry looks a country up by its alpha-2, alpha-3 or numeric code
+func (cs *countryService) GetCountry(ctx context.Context, code string) (res *entities.Country, err error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	snapshot := cs.getSnapshot(ctx)
+
+	var index int
+	var ok bool
+	switch {
+	case isDigits(code) && len(code) <= numericCodeLength:
+		index, ok = snapshot.byNumeric[fmt.Sprintf("%03s", code)]
+	case isLetters(code) && len(code) == 2:
+		index, ok = snapshot.byAlpha2[code]
+	case isLetters(code) && len(code) == 3:
+		index, ok = snapshot.byAlpha3[code]
+	default:
+		return nil, domainError.ErrInvalidCountryCode
+	}
+
+	if !ok {
+		return nil, domainError.ErrCountryNotFound
+	}
+
+	country := snapshot.countries[index]
+	return &country, nil
+}
+
+// FindCountries filters the dataset. Region matches case-insensitively, calling code and search
+// match by prefix, the search is done on both the dataset name and the localized name.
+func (cs *countryService) FindCountries(ctx context.Context, filter entities.CountryFilter) (res []entities.Country, err error) {
+	region := normalizeRegion(filter.Region)
+	callingCode := strings.TrimPrefix(strings.TrimSpace(filter.CallingCode), "+")
+	search := foldName(strings.TrimSpace(filter.Search))
+
+	res = make([]entities.Country, 0)
+	for _, country := range cs.getSnapshot(ctx).countries {
+		if region != "" && normalizeRegion(country.Region) != region {
+			continue
+		}
+
+		if callingCode != "" && !hasCallingCodePrefix(country, callingCode) {
+			continue
+		}
+
+		if search != "" && !strings.HasPrefix(foldName(country.Name), search) &&
+			!strings.HasPrefix(foldName(cs.localizationService.LocalizedName(country, filter.Language)), search) {
+			continue
+		}
+
+		res = append(res, country)
+	}
+
+	return res, nil
+}
+
+// SyncCountries fetches the dataset from the upstream and shares it with every instance through Redis
+func (cs *countryService) SyncCountries(ctx context.Context) (updated bool, err error) {
+	current, err := cs.countryRepo.GetCountriesRedis(ctx)
+	if err != nil {
+		return false, fmt.Errorf(errorSyncCountries, domainError.ErrFailedSyncCountries, err)
+	}
+
+	etag := ""
+	if current != nil {
+		etag = current.ETag
+	}
+
+	dataset, err := cs.countryRepo.FetchCountriesUpstream(ctx, etag)
+	if err != nil {
+		return false, fmt.Errorf(errorSyncCountries, domainError.ErrFailedSyncCountries, err)
+	}
+
+	if dataset == nil {
+		return false, nil
+	}
+
+	if err = validateCountries(dataset.Countries); err != nil {
+		return false, fmt.Errorf(errorSyncCountries, domainError.ErrFailedSyncCountries, err)
+	}
+
+	dataset.SyncedAt = time.Now().Unix()
+	if err = cs.countryRepo.SetCountriesRedis(ctx, *dataset); err != nil {
+		return false, fmt.Errorf(errorSyncCountries, domainError.ErrFailedSyncCountries, err)
+	}
+
+	cs.mu.Lock()
+	cs.snapshot = newCountrySnapshot(dataset.ETag, dataset.Countries)
+	cs.checkedAt = time.Now()
+	cs.mu.Unlock()
+
+	return true, nil
+}
+
+// getSnapshot serves the dataset from memory. The synced copy in Redis is picked up at most once
+// per check interval, Redis failures keep the current dataset so requests never depend on the network.
+func (cs *countryService) getSnapshot(ctx context.Context) *countrySnapshot {
+	cs.mu.RLock()
+	snapshot := cs.snapshot
+	fresh := time.Since(cs.checkedAt) < countryCheckInterval
+	cs.mu.RUnlock()
+
+	if fresh {
+		return snapshot
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if time.Since(cs.checkedAt) >= countryCheckInterval {
+		cs.checkedAt = time.Now()
+		cs.loadSyncedCountries(ctx)
+	}
+
+	return cs.snapshot
+}
+
+// loadSyncedCountries must be called with the write lock held
+func (cs *countryService) loadSyncedCountries(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), countryCheckTimeout)
+	defer cancel()
+
+	etag, err := cs.countryRepo.GetCountriesETagRedis(ctx)
+	if err != nil {
+		cs.logger.WarnContext(ctx, "failed to check synced countries", "error", err)
+		return
+	}
+
+	if etag == "" || etag == cs.snapshot.etag {
+		return
+	}
+
+	dataset, err := cs.countryRepo.GetCountriesRedis(ctx)
+	if err != nil {
+		cs.logger.WarnContext(ctx, "failed to load synced countries", "error", err)
+		return
+	}
+
+	if dataset == nil || validateCountries(dataset.Countries) != nil {
+		return
+	}
+
+	cs.snapshot = newCountrySnapshot(dataset.ETag, dataset.Countries)
+}
+
+func newCountrySnapshot(etag string, countries []entities.Country) *countrySnapshot {
+	snapshot := &countrySnapshot{
+		etag:      etag,
+		countries: countries,
+		byAlpha2:  make(map[string]int, len(countries)),
+		byAlpha3:  make(map[string]int, len(countries)),
+		byNumeric: make(map[string]int, len(countries)),
+	}
+
+	for i, country := range countries {
+		snapshot.byAlpha2[strings.ToUpper(country.Alpha2)] = i
+		snapshot.byAlpha3[strings.ToUpper(country.Alpha3)] = i
+		if country.Numeric != "" {
+			snapshot.byNumeric[country.Numeric] = i
+		}
+	}
+
+	return snapshot
+}
+
+// validateCountries rejects datasets that would break lookups, e.g. an empty or truncated upstream response
+func validateCountries(countries []entities.Country) error {
+	if len(countries) == 0 {
+		return fmt.Errorf("empty country dataset")
+	}
+
+	for _, country := range countries {
+		if len(country.Alpha2) != 2 || len(country.Alpha3) != 3 || country.Name == "" {
+			return fmt.Errorf("invalid country entry %q", country.Alpha2)
+		}
+
+		if country.Numeric != "" && (len(country.Numeric) != numericCodeLength || !isDigits(country.Numeric)) {
+			return fmt.Errorf("invalid numeric code for country %q", country.Alpha2)
+		}
+	}
+
+	return nil
+}
+
+func hasCallingCodePrefix(country entities.Country, prefix string) bool {
+	for _, code := range country.CallingCodes {
+		if strings.HasPrefix(code, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeRegion lets "north_america", "North-America" and "north america" match the same region
+func normalizeRegion(region string) string {
+	region = strings.NewReplacer("_", " ", "-", " ").Replace(region)
+	return strings.ToLower(strings.Join(strings.Fields(region), " "))
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) == -1
+}
+
+func isLetters(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return r > unicode.MaxASCII || !unicode.IsLetter(r) }) == -1
+}
@@ -2,13 +2,45 @@ package country
 
 import (
 	"github.com/google/wire"
+	"github.com/winartodev/apollo-be/infrastructure/provider"
 	"github.com/winartodev/apollo-be/modules/country/delivery/http"
+	"github.com/winartodev/apollo-be/modules/country/delivery/job"
+	countryService "github.com/winartodev/apollo-be/modules/country/domain/service"
+	countryRepo "github.com/winartodev/apollo-be/modules/country/repository"
+	countryUseCase "github.com/winartodev/apollo-be/modules/country/usecase"
+)
+
+var repositorySet = wire.NewSet(
+	// Repository implementations
+	countryRepo.NewCountryRepository,
+)
+
+var serviceSet = wire.NewSet(
+	// Domain services
+	countryService.NewCountryService,
+	countryService.NewCountryLocalizationService,
+)
+
+var useCaseSet = wire.NewSet(
+	// Use cases
+	countryUseCase.NewCountryUseCase,
 )
 
 var handlerSet = wire.NewSet(
+	// HTTP Handlers
 	http.NewCountryHandler,
 )
 
+var jobSet = wire.NewSet(
+	// Background jobs
+	job.NewCountryRefreshJob,
+)
+
 var moduleSet = wire.NewSet(
+	provider.InfraProviderSet,
+	repositorySet,
+	serviceSet,
+	useCaseSet,
 	handlerSet,
+	jobSet,
 )
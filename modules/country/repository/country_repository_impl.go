@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/config"
+	redisInfra "github.com/winartodev/apollo-be/infrastructure/redis"
+	"github.com/winartodev/apollo-be/modules/country/domain/entities"
+	"github.com/winartodev/apollo-be/modules/country/domain/repository"
+)
+
+const (
+	countriesRedisKey     = "countries:dataset"
+	countriesETagRedisKey = "countries:etag"
+
+	// upstreamMaxBodySize guards against a misbehaving upstream, the whole dataset is well below 1 MB
+	upstreamMaxBodySize = 5 << 20
+
+	errorUnexpectedUpstreamStatus = "unexpected upstream status %d"
+)
+
+//go:embed data/countries.json
+var embeddedCountries []byte
+
+type CountryRepositoryImpl struct {
+	*redisInfra.Redis
+	client      *http.Client
+	upstreamURL string
+	countries   []entities.Country
+}
+
+func NewCountryRepository(redisClient *redisInfra.Redis, countryConfig *config.Country) (repository.CountryRepository, error) {
+	var countries []entities.Country
+	if err := json.Unmarshal(embeddedCountries, &countries); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded countries: %w", err)
+	}
+
+	return &CountryRepositoryImpl{
+		Redis:       redisClient,
+		client:      &http.Client{Timeout: countryConfig.Refresh.GetTimeout()},
+		upstreamURL: countryConfig.Refresh.UpstreamURL,
+		countries:   countries,
+	}, nil
+}
+
+func (r *CountryRepositoryImpl) GetEmbeddedCountries() (res []entities.Country, err error) {
+	res = make([]entities.Country, len(r.countries))
+	copy(res, r.countries)
+
+	return res, nil
+}
+
+func (r *CountryRepositoryImpl) GetCountriesRedis(ctx context.Context) (res *entities.CountryDataset, err error) {
+	err = r.Redis.Get(ctx, countriesRedisKey, &res)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *CountryRepositoryImpl) GetCountriesETagRedis(ctx context.Context) (res string, err error) {
+	err = r.Redis.Get(ctx, countriesETagRedisKey, &res)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return res, nil
+}
+
+// SetCountriesRedis stores the dataset before its ETag, so instances that see a new ETag always find the matching dataset
+func (r *CountryRepositoryImpl) SetCountriesRedis(ctx context.Context, data entities.CountryDataset) (err error) {
+	if err = r.Redis.Set(ctx, countriesRedisKey, data); err != nil {
+		return err
+	}
+
+	return r.Redis.Set(ctx, countriesETagRedisKey, data.ETag)
+}
+
+// FetchCountriesUpstream returns nil when the upstream reports the dataset as not modified.
+// A content hash is used as ETag when the upstream doesn't send one.
+func (r *CountryRepositoryImpl) FetchCountriesUpstream(ctx context.Context, etag string) (res *entities.CountryDataset, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.upstreamURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(errorUnexpectedUpstreamStatus, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, upstreamMaxBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	res = &entities.CountryDataset{
+		ETag: resp.Header.Get("ETag"),
+	}
+
+	if res.ETag == "" {
+		res.ETag = fmt.Sprintf(`W/"%x"`, sha256.Sum256(body))
+	}
+
+	if res.ETag == etag {
+		return nil, nil
+	}
+
+	if err = json.Unmarshal(body, &res.Countries); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"golang.org/x/text/collate"
+
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/country/domain/entities"
+	"github.com/winartodev/apollo-be/modules/country/domain/service"
+	"github.com/winartodev/apollo-be/modules/country/usecase/dto"
+)
+
+type CountryUseCase interface {
+	GetCountries(ctx context.Context, filter dto.CountryFilterDto) (res *dto.CountryListDto, err error)
+	GetCountry(ctx context.Context, code string, acceptLanguage string) (res *dto.CountryDto, err error)
+	SyncCountries(ctx context.Context) (updated bool, err error)
+}
+
+type countryUseCase struct {
+	countryService      service.CountryService
+	localizationService service.CountryLocalizationService
+}
+
+func NewCountryUseCase(countryService service.CountryService, localizationService service.CountryLocalizationService) (CountryUseCase, error) {
+	return &countryUseCase{
+		countryService:      countryService,
+		localizationService: localizationService,
+	}, nil
+}
+
+// GetCountries returns the filtered countries with localized names, sorted by name in the resolved language
+func (cu *countryUseCase) GetCountries(ctx context.Context, filter dto.CountryFilterDto) (res *dto.CountryListDto, err error) {
+	tag := cu.localizationService.ResolveLanguage(filter.AcceptLanguage)
+
+	countries, err := cu.countryService.FindCountries(ctx, entities.CountryFilter{
+		Region:      filter.Region,
+		CallingCode: filter.CallingCode,
+		Search:      filter.Search,
+		Language:    tag,
+	})
+	if err != nil {
+		return nil, domainError.ErrFailedGetCountries
+	}
+
+	res = &dto.CountryListDto{
+		Language:  tag.String(),
+		Countries: make([]dto.CountryDto, len(countries)),
+	}
+
+	for i := range countries {
+		res.Countries[i] = countries[i].ToUseCaseData()
+		res.Countries[i].Name = cu.localizationService.LocalizedName(countries[i], tag)
+	}
+
+	collator := collate.New(tag, collate.IgnoreCase, collate.IgnoreDiacritics)
+	sort.SliceStable(res.Countries, func(i, j int) bool {
+		return collator.CompareString(res.Countries[i].Name, res.Countries[j].Name) < 0
+	})
+
+	return res, nil
+}
+
+func (cu *countryUseCase) GetCountry(ctx context.Context, code string, acceptLanguage string) (res *dto.CountryDto, err error) {
+	tag := cu.localizationService.ResolveLanguage(acceptLanguage)
+
+	country, err := cu.countryService.GetCountry(ctx, code)
+	if err != nil {
+		if errors.Is(err, domainError.ErrCountryNotFound) || errors.Is(err, domainError.ErrInvalidCountryCode) {
+			return nil, err
+		}
+
+		return nil, domainError.ErrFailedGetCountries
+	}
+
+	countryDto := country.ToUseCaseData()
+	countryDto.Name = cu.localizationService.LocalizedName(*country, tag)
+	countryDto.Language = tag.String()
+
+	return &countryDto, nil
+}
+
+func (cu *countryUseCase) SyncCountries(ctx context.Context) (updated bool, err error) {
+	return cu.countryService.SyncCountries(ctx)
+}
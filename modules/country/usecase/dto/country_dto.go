@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/winartodev/apollo-be/helper"
+	"github.com/winartodev/apollo-be/modules/country/delivery/dto"
+)
+
+const (
+	countryFlagPngURL = "https://flagcdn.com/w320/%s.png"
+	countryFlagSvgURL = "https://flagcdn.com/%s.svg"
+)
+
+type CountryDto struct {
+	Name         string
+	Alpha2       string
+	Alpha3       string
+	Numeric      string
+	CallingCodes []string
+	Region       string
+	Language     string
+}
+
+func (c *CountryDto) ToResponse() dto.CountryResponse {
+	code := strings.ToLower(c.Alpha2)
+
+	return dto.CountryResponse{
+		Name:    c.Name,
+		Code:    c.Alpha3,
+		Alpha2:  c.Alpha2,
+		Alpha3:  c.Alpha3,
+		Numeric: c.Numeric,
+		Region:  c.Region,
+		Flags: dto.Flag{
+			Png: fmt.Sprintf(countryFlagPngURL, code),
+			Svg: fmt.Sprintf(countryFlagSvgURL, code),
+		},
+		CallingCodes:    helper.GetFirstElement(c.CallingCodes),
+		CallingCodeList: c.CallingCodes,
+	}
+}
+
+// CountryListDto holds countries localized in Language
+type CountryListDto struct {
+	Language  string
+	Countries []CountryDto
+}
+
+type CountryFilterDto struct {
+	Region         string
+	CallingCode    string
+	Search         string
+	AcceptLanguage string
+}
+
+func NewCountryFilterDto(req dto.CountryQueryRequest, acceptLanguage string) CountryFilterDto {
+	return CountryFilterDto{
+		Region:         req.Region,
+		CallingCode:    req.CallingCode,
+		Search:         req.Search,
+		AcceptLanguage: acceptLanguage,
+	}
+}
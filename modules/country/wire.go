@@ -5,10 +5,37 @@ package country
 
 import (
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
 	"github.com/winartodev/apollo-be/modules/country/delivery/http"
+	"github.com/winartodev/apollo-be/modules/country/delivery/job"
+	"github.com/winartodev/apollo-be/modules/country/usecase"
 )
 
-func InitializeCountryAPI() (*http.CountryHandler, error) {
+func InitializeCountryAPI(
+	redis *redis.Client,
+	countryConfig *config.Country,
+	appLogger *logger.Logger,
+) (*http.CountryHandler, error) {
 	wire.Build(moduleSet)
 	return &http.CountryHandler{}, nil
 }
+
+func InitializeCountryUseCase(
+	redis *redis.Client,
+	countryConfig *config.Country,
+	appLogger *logger.Logger,
+) (usecase.CountryUseCase, error) {
+	wire.Build(moduleSet)
+	return nil, nil
+}
+
+func InitializeCountryRefreshJob(
+	redis *redis.Client,
+	countryConfig *config.Country,
+	appLogger *logger.Logger,
+) (*job.CountryRefreshJob, error) {
+	wire.Build(moduleSet)
+	return &job.CountryRefreshJob{}, nil
+}
@@ -7,12 +7,89 @@
 package country
 
 import (
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/config"
+	"github.com/winartodev/apollo-be/infrastructure/logger"
+	redis2 "github.com/winartodev/apollo-be/infrastructure/redis"
 	"github.com/winartodev/apollo-be/modules/country/delivery/http"
+	"github.com/winartodev/apollo-be/modules/country/delivery/job"
+	"github.com/winartodev/apollo-be/modules/country/domain/service"
+	"github.com/winartodev/apollo-be/modules/country/repository"
+	"github.com/winartodev/apollo-be/modules/country/usecase"
 )
 
 // Injectors from wire.go:
 
-func InitializeCountryAPI() (*http.CountryHandler, error) {
-	countryHandler := http.NewCountryHandler()
+func InitializeCountryAPI(redis3 *redis.Client, countryConfig *config.Country, appLogger *logger.Logger) (*http.CountryHandler, error) {
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	countryRepository, err := repository.NewCountryRepository(redisRedis, countryConfig)
+	if err != nil {
+		return nil, err
+	}
+	countryLocalizationService, err := service.NewCountryLocalizationService()
+	if err != nil {
+		return nil, err
+	}
+	countryService, err := service.NewCountryService(countryRepository, countryLocalizationService, appLogger)
+	if err != nil {
+		return nil, err
+	}
+	countryUseCase, err := usecase.NewCountryUseCase(countryService, countryLocalizationService)
+	if err != nil {
+		return nil, err
+	}
+	countryHandler := http.NewCountryHandler(countryUseCase)
 	return countryHandler, nil
 }
+
+func InitializeCountryUseCase(redis3 *redis.Client, countryConfig *config.Country, appLogger *logger.Logger) (usecase.CountryUseCase, error) {
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	countryRepository, err := repository.NewCountryRepository(redisRedis, countryConfig)
+	if err != nil {
+		return nil, err
+	}
+	countryLocalizationService, err := service.NewCountryLocalizationService()
+	if err != nil {
+		return nil, err
+	}
+	countryService, err := service.NewCountryService(countryRepository, countryLocalizationService, appLogger)
+	if err != nil {
+		return nil, err
+	}
+	countryUseCase, err := usecase.NewCountryUseCase(countryService, countryLocalizationService)
+	if err != nil {
+		return nil, err
+	}
+	return countryUseCase, nil
+}
+
+func InitializeCountryRefreshJob(redis3 *redis.Client, countryConfig *config.Country, appLogger *logger.Logger) (*job.CountryRefreshJob, error) {
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	countryRepository, err := repository.NewCountryRepository(redisRedis, countryConfig)
+	if err != nil {
+		return nil, err
+	}
+	countryLocalizationService, err := service.NewCountryLocalizationService()
+	if err != nil {
+		return nil, err
+	}
+	countryService, err := service.NewCountryService(countryRepository, countryLocalizationService, appLogger)
+	if err != nil {
+		return nil, err
+	}
+	countryUseCase, err := usecase.NewCountryUseCase(countryService, countryLocalizationService)
+	if err != nil {
+		return nil, err
+	}
+	countryRefreshJob := job.NewCountryRefreshJob(countryUseCase, countryConfig, appLogger)
+	return countryRefreshJob, nil
+}
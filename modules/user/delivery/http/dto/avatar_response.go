@@ -0,0 +1,17 @@
+package dto
+
+// AvatarResponse represents the URLs of every avatar size
+// swagger:model AvatarResponse
+type AvatarResponse struct {
+	// 64x64 avatar
+	// example: https://cdn.example.com/avatars/1/1700000000/small.jpg
+	Small string `json:"small"`
+
+	// 256x256 avatar
+	// example: https://cdn.example.com/avatars/1/1700000000/medium.jpg
+	Medium string `json:"medium"`
+
+	// 512x512 avatar
+	// example: https://cdn.example.com/avatars/1/1700000000/large.jpg
+	Large string `json:"large"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// PreferencesResponse represents the user preferences with defaults applied
+// swagger:model PreferencesResponse
+type PreferencesResponse struct {
+	// BCP 47 language tag
+	// example: en-US
+	Locale string `json:"locale"`
+
+	// IANA time zone name
+	// example: Asia/Jakarta
+	Timezone string `json:"timezone"`
+
+	// Whether the user accepts marketing emails
+	// example: false
+	MarketingEmails bool `json:"marketing_emails"`
+
+	// Preferred channel to receive OTP codes
+	// example: email
+	OtpChannel string `json:"otp_channel"`
+}
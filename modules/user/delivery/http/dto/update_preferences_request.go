@@ -0,0 +1,23 @@
+package dto
+
+// UpdatePreferencesRequest represents a partial update of the user preferences,
+// only the provided fields are changed
+// swagger:model UpdatePreferencesRequest
+type UpdatePreferencesRequest struct {
+	// BCP 47 language tag
+	// example: id-ID
+	Locale *string `json:"locale,omitempty"`
+
+	// IANA time zone name
+	// example: Asia/Jakarta
+	Timezone *string `json:"timezone,omitempty"`
+
+	// Whether the user accepts marketing emails
+	// example: true
+	MarketingEmails *bool `json:"marketing_emails,omitempty"`
+
+	// Preferred channel to receive OTP codes, sms is rejected with otp_channel_unavailable until it is supported
+	// enum: email,sms
+	// example: email
+	OtpChannel *string `json:"otp_channel,omitempty" validate:"omitempty,oneof=email sms"`
+}
@@ -1,19 +1,18 @@
 package dto
 
-import "time"
-
 type UserResponse struct {
-	ID              int64      `json:"id"`
-	Username        string     `json:"username"`
-	Email           string     `json:"email"`
-	PhoneNumber     string     `json:"phone_number"`
-	FirstName       string     `json:"first_name"`
-	LastName        string     `json:"last_name"`
-	IsActive        bool       `json:"is_active"`
-	IsEmailVerified bool       `json:"is_email_verified"`
-	IsPhoneVerified bool       `json:"is_phone_verified"`
-	LastLogin       *time.Time `json:"last_login"`
-	CreatedAt       *time.Time `json:"created_at"`
-	UpdatedAt       *time.Time `json:"updated_at"`
-	DeletedAt       *time.Time `json:"deleted_at"`
+	ID              int64           `json:"id"`
+	Username        string          `json:"username"`
+	Email           string          `json:"email"`
+	PhoneNumber     string          `json:"phone_number"`
+	FirstName       string          `json:"first_name"`
+	LastName        string          `json:"last_name"`
+	Avatar          *AvatarResponse `json:"avatar"`
+	IsActive        bool            `json:"is_active"`
+	IsEmailVerified bool            `json:"is_email_verified"`
+	IsPhoneVerified bool            `json:"is_phone_verified"`
+	LastLogin       *string         `json:"last_login" example:"2026-10-19T08:30:00Z"`
+	CreatedAt       *string         `json:"created_at" example:"2026-10-19T08:30:00Z"`
+	UpdatedAt       *string         `json:"updated_at" example:"2026-10-19T08:30:00Z"`
+	DeletedAt       *string         `json:"deleted_at"`
 }
@@ -1,23 +1,40 @@
 package http
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/winartodev/apollo-be/infrastructure/http/response"
 	"github.com/winartodev/apollo-be/infrastructure/middleware"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/user/delivery/http/dto"
 	"github.com/winartodev/apollo-be/modules/user/usecase"
+	useCaseDto "github.com/winartodev/apollo-be/modules/user/usecase/dto"
+)
+
+const (
+	avatarFormField = "avatar"
+
+	// multipartOverhead leaves room for the multipart boundaries and headers around the file
+	multipartOverhead = 1 << 20
 )
 
 type UserHandler struct {
-	middleware  *middleware.Middleware
-	userUseCase usecase.UserUseCase
+	middleware        *middleware.Middleware
+	userUseCase       usecase.UserUseCase
+	avatarUseCase     usecase.AvatarUseCase
+	preferenceUseCase usecase.PreferenceUseCase
 }
 
-func NewUserHandler(userUseCase usecase.UserUseCase, middleware *middleware.Middleware) *UserHandler {
+func NewUserHandler(userUseCase usecase.UserUseCase, avatarUseCase usecase.AvatarUseCase, preferenceUseCase usecase.PreferenceUseCase, middleware *middleware.Middleware) *UserHandler {
 	return &UserHandler{
-		middleware:  middleware,
-		userUseCase: userUseCase,
+		middleware:        middleware,
+		userUseCase:       userUseCase,
+		avatarUseCase:     avatarUseCase,
+		preferenceUseCase: preferenceUseCase,
 	}
 }
 
@@ -28,6 +45,125 @@ func (uh *UserHandler) GetUserInfo(c echo.Context) error {
 		return response.FailedResponse(c, http.StatusInternalServerError, err)
 	}
 
+	resp := res.ToResponse()
+	resp.Avatar = uh.avatarUseCase.GetAvatar(res.AvatarKey).ToResponse()
+
+	return response.SuccessResponse(c, http.StatusOK, "OK", resp, nil)
+}
+
+// UploadAvatar godoc
+//
+//	@Summary		Upload avatar
+//	@Description	Upload a new profile picture, the image is resized into small, medium and large sizes
+//	@Tags			User
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			avatar	formData	file										true	"Avatar image (jpeg, png, gif or webp)"
+//	@Success		200		{object}	response.Response{data=dto.AvatarResponse}	"Avatar uploaded successfully"
+//	@Failure		400		{object}	response.ErrorResponse						"Invalid avatar file"
+//	@Failure		401		{object}	response.ErrorResponse						"Unauthorized"
+//	@Failure		413		{object}	response.ErrorResponse						"Avatar is too large"
+//	@Failure		415		{object}	response.ErrorResponse						"Unsupported image type"
+//	@Failure		500		{object}	response.ErrorResponse						"Internal server error"
+//	@Router			/users/me/avatar [put]
+func (uh *UserHandler) UploadAvatar(c echo.Context) error {
+	maxSize := uh.avatarUseCase.MaxAvatarSize()
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxSize+multipartOverhead)
+
+	file, err := c.FormFile(avatarFormField)
+	if err != nil {
+		return avatarReadFailed(c, err)
+	}
+
+	if file.Size > maxSize {
+		return response.FailedResponse(c, http.StatusRequestEntityTooLarge, domainError.ErrAvatarTooLarge)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return response.FailedResponse(c, http.StatusBadRequest, domainError.ErrInvalidAvatarFile)
+	}
+
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxSize+1))
+	if err != nil {
+		return avatarReadFailed(c, err)
+	}
+
+	ctx := c.Request().Context()
+	res, err := uh.avatarUseCase.UploadAvatar(ctx, data)
+	if err != nil {
+		return response.FailedResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return response.SuccessResponse(c, http.StatusOK, "OK", res.ToResponse(), nil)
+}
+
+// avatarReadFailed reports a body cut off by the size limit as too large, any other error as an invalid file
+func avatarReadFailed(c echo.Context, err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return response.FailedResponse(c, http.StatusRequestEntityTooLarge, domainError.ErrAvatarTooLarge)
+	}
+
+	return response.FailedResponse(c, http.StatusBadRequest, domainError.ErrInvalidAvatarFile)
+}
+
+// GetPreferences godoc
+//
+//	@Summary		Get preferences
+//	@Description	Get the current user preferences, unset preferences are returned with their default value
+//	@Tags			User
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response{data=dto.PreferencesResponse}	"User preferences"
+//	@Failure		401	{object}	response.ErrorResponse							"Unauthorized"
+//	@Failure		500	{object}	response.ErrorResponse							"Internal server error"
+//	@Router			/users/me/preferences [get]
+func (uh *UserHandler) GetPreferences(c echo.Context) error {
+	ctx := c.Request().Context()
+	res, err := uh.preferenceUseCase.GetCurrentUserPreferences(ctx)
+	if err != nil {
+		return response.FailedResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return response.SuccessResponse(c, http.StatusOK, "OK", res.ToResponse(), nil)
+}
+
+// UpdatePreferences godoc
+//
+//	@Summary		Update preferences
+//	@Description	Partially update the current user preferences, omitted fields are left unchanged
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		dto.UpdatePreferencesRequest					true	"Preferences to update"
+//	@Success		200		{object}	response.Response{data=dto.PreferencesResponse}	"Preferences updated successfully"
+//	@Failure		400		{object}	response.ErrorResponse							"Invalid preference value"
+//	@Failure		401		{object}	response.ErrorResponse							"Unauthorized"
+//	@Failure		422		{object}	response.ErrorResponse							"Validation error, or an otp_channel that is not available yet"
+//	@Failure		500		{object}	response.ErrorResponse							"Internal server error"
+//	@Router			/users/me/preferences [patch]
+func (uh *UserHandler) UpdatePreferences(c echo.Context) error {
+	var req dto.UpdatePreferencesRequest
+
+	if err := c.Bind(&req); err != nil {
+		return response.FailedResponse(c, http.StatusBadRequest, fmt.Errorf(response.ErrInvalidRequestPayload, err))
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.ValidationErrResponse(c, err)
+	}
+
+	ctx := c.Request().Context()
+	res, err := uh.preferenceUseCase.UpdateCurrentUserPreferences(ctx, useCaseDto.NewUpdatePreferencesDto(req))
+	if err != nil {
+		return response.FailedResponse(c, http.StatusInternalServerError, err)
+	}
+
 	return response.SuccessResponse(c, http.StatusOK, "OK", res.ToResponse(), nil)
 }
 
@@ -35,6 +171,9 @@ func (uh *UserHandler) RegisterRoutes(api *echo.Group) error {
 
 	user := api.Group("/users")
 	user.GET("/me", uh.GetUserInfo, uh.middleware.HandleWithAuth())
+	user.PUT("/me/avatar", uh.UploadAvatar, uh.middleware.HandleWithAuth())
+	user.GET("/me/preferences", uh.GetPreferences, uh.middleware.HandleWithAuth())
+	user.PATCH("/me/preferences", uh.UpdatePreferences, uh.middleware.HandleWithAuth())
 
 	return nil
 }
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"github.com/winartodev/apollo-be/modules/user/usecase/dto"
+)
+
+const (
+	PreferenceLocale          = "locale"
+	PreferenceTimezone        = "timezone"
+	PreferenceMarketingEmails = "marketing_emails"
+	PreferenceOtpChannel      = "otp_channel"
+
+	OtpChannelEmail = "email"
+	OtpChannelSMS   = "sms"
+)
+
+type Preferences struct {
+	Locale          string `json:"locale"`
+	Timezone        string `json:"timezone"`
+	MarketingEmails bool   `json:"marketing_emails"`
+	OtpChannel      string `json:"otp_channel"`
+}
+
+func (p *Preferences) ToUseCaseData() dto.PreferencesDto {
+	return dto.PreferencesDto{
+		Locale:          p.Locale,
+		Timezone:        p.Timezone,
+		MarketingEmails: p.MarketingEmails,
+		OtpChannel:      p.OtpChannel,
+	}
+}
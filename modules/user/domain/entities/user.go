@@ -13,6 +13,7 @@ type User struct {
 	PhoneNumber     string
 	FirstName       string
 	LastName        string
+	AvatarKey       string
 	IsActive        bool
 	IsEmailVerified bool
 	IsPhoneVerified bool
@@ -30,6 +31,7 @@ func (u *User) ToUseCaseData() dto.UserDto {
 		PhoneNumber:     u.PhoneNumber,
 		FirstName:       u.FirstName,
 		LastName:        u.LastName,
+		AvatarKey:       u.AvatarKey,
 		IsActive:        u.IsActive,
 		IsEmailVerified: u.IsEmailVerified,
 		IsPhoneVerified: u.IsPhoneVerified,
@@ -10,4 +10,8 @@ type UserRepository interface {
 	GetUserByIDDB(ctx context.Context, id int64) (user *entity.User, err error)
 	GetUserByEmailDB(ctx context.Context, email string) (user *entity.User, err error)
 	GetUserByUsernameDB(ctx context.Context, username string) (user *entity.User, err error)
+	GetUserByPhoneNumberDB(ctx context.Context, phoneNumber string) (user *entity.User, err error)
+	UpdateAvatarKeyDB(ctx context.Context, id int64, avatarKey *string) (err error)
+	GetPreferencesDB(ctx context.Context, id int64) (res []byte, err error)
+	UpdatePreferencesDB(ctx context.Context, id int64, preferences []byte) (res []byte, err error)
 }
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/winartodev/apollo-be/infrastructure/imaging"
+	"github.com/winartodev/apollo-be/infrastructure/storage"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/user/domain/repository"
+)
+
+const (
+	avatarKeyFormat     = "avatars/%d/%d"
+	avatarFileFormat    = "%s/%s.jpg"
+	avatarMaxDimension  = 4096
+	avatarMaxPixels     = 12_000_000
+	avatarSizeSmall     = "small"
+	avatarSizeMedium    = "medium"
+	avatarSizeLarge     = "large"
+	avatarSmallPixels   = 64
+	avatarMediumPixels  = 256
+	avatarLargePixels   = 512
+	avatarStoredContent = imaging.ContentTypeJPEG
+)
+
+// avatarSizes lists every size generated for an uploaded avatar
+var avatarSizes = []struct {
+	name   string
+	pixels int
+}{
+	{avatarSizeSmall, avatarSmallPixels},
+	{avatarSizeMedium, avatarMediumPixels},
+	{avatarSizeLarge, avatarLargePixels},
+}
+
+type AvatarURLs struct {
+	Small  string
+	Medium string
+	Large  string
+}
+
+type AvatarService interface {
+	UploadAvatar(ctx context.Context, userID int64, data []byte) (avatarKey string, err error)
+	DeleteAvatar(ctx context.Context, avatarKey string) (err error)
+	UpdateAvatarKey(ctx context.Context, userID int64, avatarKey string) (err error)
+	GetAvatarURLs(avatarKey string) *AvatarURLs
+}
+
+type avatarService struct {
+	userRepo repository.UserRepository
+	storage  storage.Storage
+}
+
+func NewAvatarService(userRepo repository.UserRepository, storage storage.Storage) (AvatarService, error) {
+	return &avatarService{
+		userRepo: userRepo,
+		storage:  storage,
+	}, nil
+}
+
+// UploadAvatar validates the image, resizes it into every standard size and stores them.
+// Each upload gets a new key so cached URLs of the previous avatar are never served stale.
+func (as *avatarService) UploadAvatar(ctx context.Context, userID int64, data []byte) (avatarKey string, err error) {
+	if _, err := imaging.DetectContentType(data); err != nil {
+		return "", domainError.ErrUnsupportedAvatarType
+	}
+
+	img, err := imaging.Decode(data, avatarMaxDimension, avatarMaxPixels)
+	if err != nil {
+		if errors.Is(err, imaging.ErrImageTooLarge) {
+			return "", domainError.ErrAvatarTooLarge
+		}
+
+		return "", domainError.ErrInvalidAvatarFile
+	}
+
+	avatarKey = fmt.Sprintf(avatarKeyFormat, userID, time.Now().UnixNano())
+	for _, size := range avatarSizes {
+		encoded, err := imaging.EncodeJPEG(imaging.ResizeSquare(img, size.pixels))
+		if err != nil {
+			return "", domainError.ErrFailedUploadAvatar
+		}
+
+		key := fmt.Sprintf(avatarFileFormat, avatarKey, size.name)
+		err = as.storage.Put(ctx, key, bytes.NewReader(encoded), int64(len(encoded)), avatarStoredContent)
+		if err != nil {
+			_ = as.DeleteAvatar(ctx, avatarKey)
+			return "", domainError.ErrFailedUploadAvatar
+		}
+	}
+
+	return avatarKey, nil
+}
+
+func (as *avatarService) DeleteAvatar(ctx context.Context, avatarKey string) (err error) {
+	if avatarKey == "" {
+		return nil
+	}
+
+	for _, size := range avatarSizes {
+		if deleteErr := as.storage.Delete(ctx, fmt.Sprintf(avatarFileFormat, avatarKey, size.name)); deleteErr != nil {
+			err = deleteErr
+		}
+	}
+
+	return err
+}
+
+func (as *avatarService) UpdateAvatarKey(ctx context.Context, userID int64, avatarKey string) (err error) {
+	return as.userRepo.UpdateAvatarKeyDB(ctx, userID, &avatarKey)
+}
+
+func (as *avatarService) GetAvatarURLs(avatarKey string) *AvatarURLs {
+	if avatarKey == "" {
+		return nil
+	}
+
+	return &AvatarURLs{
+		Small:  as.storage.URL(fmt.Sprintf(avatarFileFormat, avatarKey, avatarSizeSmall)),
+		Medium: as.storage.URL(fmt.Sprintf(avatarFileFormat, avatarKey, avatarSizeMedium)),
+		Large:  as.storage.URL(fmt.Sprintf(avatarFileFormat, avatarKey, avatarSizeLarge)),
+	}
+}
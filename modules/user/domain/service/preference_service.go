@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	_ "time/tzdata" // timezone validation must not depend on the host having tzdata installed
+
+	"golang.org/x/text/language"
+
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/user/domain/entities"
+	"github.com/winartodev/apollo-be/modules/user/domain/repository"
+)
+
+const (
+	errorInvalidPreference = "%w: %s"
+)
+
+// preferenceField describes a single preference key, its default and how its value is validated
+type preferenceField struct {
+	defaultValue interface{}
+	validate     func(value interface{}) (interface{}, error)
+}
+
+// preferenceSchema lists every supported preference key, unknown keys are rejected on update
+var preferenceSchema = map[string]preferenceField{
+	entities.PreferenceLocale: {
+		defaultValue: "en",
+		validate:     validateLocale,
+	},
+	entities.PreferenceTimezone: {
+		defaultValue: "UTC",
+		validate:     validateTimezone,
+	},
+	entities.PreferenceMarketingEmails: {
+		defaultValue: false,
+		validate:     validateBool,
+	},
+	entities.PreferenceOtpChannel: {
+		defaultValue: entities.OtpChannelEmail,
+		validate:     validateOtpChannel,
+	},
+}
+
+type PreferenceService interface {
+	GetPreferences(ctx context.Context, userID int64) (res *entities.Preferences, err error)
+	UpdatePreferences(ctx context.Context, userID int64, updates map[string]interface{}) (res *entities.Preferences, err error)
+}
+
+type preferenceService struct {
+	userRepo repository.UserRepository
+}
+
+func NewPreferenceService(userRepo repository.UserRepository) (PreferenceService, error) {
+	return &preferenceService{
+		userRepo: userRepo,
+	}, nil
+}
+
+func (ps *preferenceService) GetPreferences(ctx context.Context, userID int64) (res *entities.Preferences, err error) {
+	stored, err := ps.userRepo.GetPreferencesDB(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored == nil {
+		return nil, domainError.ErrUserNotFound
+	}
+
+	return ps.buildPreferences(stored)
+}
+
+// UpdatePreferences validates every key against the schema before merging them into the stored preferences.
+func (ps *preferenceService) UpdatePreferences(ctx context.Context, userID int64, updates map[string]interface{}) (res *entities.Preferences, err error) {
+	validated := make(map[string]interface{}, len(updates))
+	for key, value := range updates {
+		field, ok := preferenceSchema[key]
+		if !ok {
+			return nil, fmt.Errorf(errorInvalidPreference, domainError.ErrInvalidPreference, key)
+		}
+
+		normalized, err := field.validate(value)
+		if err != nil {
+			// Parser errors only mean the value is invalid, domain errors like ErrOtpChannelUnavailable are kept
+			if !domainError.IsDomainError(err) {
+				err = domainError.ErrInvalidPreference
+			}
+
+			return nil, fmt.Errorf(errorInvalidPreference, err, key)
+		}
+
+		validated[key] = normalized
+	}
+
+	if len(validated) == 0 {
+		return ps.GetPreferences(ctx, userID)
+	}
+
+	patch, err := json.Marshal(validated)
+	if err != nil {
+		return nil, domainError.ErrFailedUpdatePreferences
+	}
+
+	stored, err := ps.userRepo.UpdatePreferencesDB(ctx, userID, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored == nil {
+		return nil, domainError.ErrUserNotFound
+	}
+
+	return ps.buildPreferences(stored)
+}
+
+// buildPreferences applies defaults for missing keys and ignores stored values that no longer pass validation.
+func (ps *preferenceService) buildPreferences(stored []byte) (res *entities.Preferences, err error) {
+	raw := make(map[string]interface{})
+	if err := json.Unmarshal(stored, &raw); err != nil {
+		return nil, domainError.ErrFailedGetPreferences
+	}
+
+	merged := make(map[string]interface{}, len(preferenceSchema))
+	for key, field := range preferenceSchema {
+		merged[key] = field.defaultValue
+		if value, ok := raw[key]; ok {
+			if normalized, err := field.validate(value); err == nil {
+				merged[key] = normalized
+			}
+		}
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, domainError.ErrFailedGetPreferences
+	}
+
+	res = &entities.Preferences{}
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, domainError.ErrFailedGetPreferences
+	}
+
+	return res, nil
+}
+
+func validateLocale(value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, domainError.ErrInvalidPreference
+	}
+
+	tag, err := language.Parse(str)
+	if err != nil {
+		return nil, err
+	}
+
+	return tag.String(), nil
+}
+
+func validateTimezone(value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok || str == "" {
+		return nil, domainError.ErrInvalidPreference
+	}
+
+	location, err := time.LoadLocation(str)
+	if err != nil {
+		return nil, err
+	}
+
+	return location.String(), nil
+}
+
+func validateBool(value interface{}) (interface{}, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return nil, domainError.ErrInvalidPreference
+	}
+
+	return b, nil
+}
+
+func validateOtpChannel(value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, domainError.ErrInvalidPreference
+	}
+
+	switch str {
+	case entities.OtpChannelEmail:
+		return str, nil
+	case entities.OtpChannelSMS:
+		// There is no SMS transport yet, accepting it would silently email the codes instead
+		return nil, domainError.ErrOtpChannelUnavailable
+	default:
+		return nil, domainError.ErrInvalidPreference
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/user/domain/entities"
+	"github.com/winartodev/apollo-be/modules/user/domain/repository"
+)
+
+// fakePreferenceRepo stores the patch as the whole preferences, enough for a single update
+type fakePreferenceRepo struct {
+	repository.UserRepository
+}
+
+func (fakePreferenceRepo) UpdatePreferencesDB(ctx context.Context, id int64, preferences []byte) ([]byte, error) {
+	return preferences, nil
+}
+
+func TestUpdatePreferencesErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		updates    map[string]interface{}
+		wantErr    error
+		wantStatus int
+	}{
+		{name: "valid", updates: map[string]interface{}{entities.PreferenceLocale: "id", entities.PreferenceTimezone: "Asia/Jakarta"}},
+		{name: "unknown key", updates: map[string]interface{}{"theme": "dark"}, wantErr: domainError.ErrInvalidPreference, wantStatus: http.StatusBadRequest},
+		{name: "wrong type", updates: map[string]interface{}{entities.PreferenceMarketingEmails: "yes"}, wantErr: domainError.ErrInvalidPreference, wantStatus: http.StatusBadRequest},
+		{name: "unparsable locale", updates: map[string]interface{}{entities.PreferenceLocale: "not a locale!"}, wantErr: domainError.ErrInvalidPreference, wantStatus: http.StatusBadRequest},
+		{name: "unknown timezone", updates: map[string]interface{}{entities.PreferenceTimezone: "Mars/Olympus"}, wantErr: domainError.ErrInvalidPreference, wantStatus: http.StatusBadRequest},
+		{name: "unknown otp channel", updates: map[string]interface{}{entities.PreferenceOtpChannel: "pigeon"}, wantErr: domainError.ErrInvalidPreference, wantStatus: http.StatusBadRequest},
+		{name: "sms otp channel", updates: map[string]interface{}{entities.PreferenceOtpChannel: entities.OtpChannelSMS}, wantErr: domainError.ErrOtpChannelUnavailable, wantStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preferences, err := NewPreferenceService(fakePreferenceRepo{})
+			if err != nil {
+				t.Fatalf("NewPreferenceService() error = %v", err)
+			}
+
+			_, err = preferences.UpdatePreferences(context.Background(), 1, tt.updates)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("UpdatePreferences() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdatePreferences() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if status := domainError.GetHTTPStatusFromError(err); status != tt.wantStatus {
+				t.Errorf("HTTP status = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ type UserService interface {
 	GetCurrentUser(ctx context.Context) (res *entities.User, err error)
 	IsEmailExists(ctx context.Context, email string) (res *domainEntity.SharedUser, err error)
 	IsUsernameExists(ctx context.Context, username string) (res *domainEntity.SharedUser, err error)
+	IsPhoneNumberExists(ctx context.Context, phoneNumber string) (res *domainEntity.SharedUser, err error)
 }
 
 type userService struct {
@@ -61,6 +62,15 @@ func (us *userService) IsUsernameExists(ctx context.Context, username string) (r
 	return us.buildToSharedUser(user), nil
 }
 
+func (us *userService) IsPhoneNumberExists(ctx context.Context, phoneNumber string) (res *domainEntity.SharedUser, err error) {
+	user, err := us.userRepo.GetUserByPhoneNumberDB(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.buildToSharedUser(user), nil
+}
+
 func (us *userService) buildToSharedUser(user *entities.User) (sharedUser *domainEntity.SharedUser) {
 	if user == nil {
 		return nil
@@ -76,5 +86,9 @@ func (us *userService) buildToSharedUser(user *entities.User) (sharedUser *domai
 		IsActive:        user.IsActive,
 		IsEmailVerified: user.IsEmailVerified,
 		IsPhoneVerified: user.IsPhoneVerified,
+		LastLogin:       user.LastLogin,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		DeletedAt:       user.DeletedAt,
 	}
 }
@@ -17,11 +17,15 @@ var repositorySet = wire.NewSet(
 var serviceSet = wire.NewSet(
 	// Domain services
 	userService.NewUserService,
+	userService.NewPreferenceService,
+	userService.NewAvatarService,
 )
 
 var useCaseSet = wire.NewSet(
 	// Use cases
 	userUseCase.NewUserUseCase,
+	userUseCase.NewPreferenceUseCase,
+	userUseCase.NewAvatarUseCase,
 )
 
 var handlerSet = wire.NewSet(
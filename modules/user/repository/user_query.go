@@ -8,7 +8,15 @@ const (
 		    usr.email,
 		    usr.first_name,
 		    usr.last_name,
-		    usr.phone_number
+		    COALESCE(usr.phone_number, ''),
+		    COALESCE(usr.avatar_key, ''),
+		    usr.is_active,
+		    usr.is_email_verified,
+		    usr.is_phone_verified,
+		    usr.last_login,
+		    usr.created_at,
+		    usr.updated_at,
+		    usr.deleted_at
 		FROM users AS usr
 		WHERE usr.id = $1
 	`
@@ -20,7 +28,38 @@ const (
 		    usr.email,
 		    usr.first_name,
 		    usr.last_name,
-		    usr.phone_number
+		    COALESCE(usr.phone_number, ''),
+		    COALESCE(usr.avatar_key, ''),
+		    usr.is_active,
+		    usr.is_email_verified,
+		    usr.is_phone_verified,
+		    usr.last_login,
+		    usr.created_at,
+		    usr.updated_at,
+		    usr.deleted_at
 		FROM users AS usr
 	`
+
+	updateAvatarKeyQuery = `
+		UPDATE users
+			SET
+			    avatar_key = $2,
+			    updated_at = $3
+		WHERE id = $1
+	`
+
+	getPreferencesQuery = `
+		SELECT usr.preferences
+		FROM users AS usr
+		WHERE usr.id = $1
+	`
+
+	updatePreferencesQuery = `
+		UPDATE users
+			SET
+			    preferences = preferences || $2::jsonb,
+			    updated_at = $3
+		WHERE id = $1
+		RETURNING preferences
+	`
 )
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/winartodev/apollo-be/infrastructure/database"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
 	"github.com/winartodev/apollo-be/modules/user/domain/entities"
 	"github.com/winartodev/apollo-be/modules/user/domain/repository"
 )
@@ -22,46 +24,104 @@ func NewUserRepository(db *database.Database) (repository.UserRepository, error)
 }
 
 func (ur *UserRepositoryImpl) GetUserByIDDB(ctx context.Context, id int64) (user *entities.User, err error) {
-	user = &entities.User{}
+	return scanUser(ur.Conn(ctx).QueryRowContext(ctx, getUserByID, id))
+}
 
-	err = ur.DB.QueryRowContext(ctx, getUserByID, id).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.FirstName,
-		&user.LastName,
-		&user.PhoneNumber,
+func (ur *UserRepositoryImpl) GetUserByEmailDB(ctx context.Context, email string) (user *entities.User, err error) {
+	return ur.getUserByField(ctx, "email", email)
+}
+
+func (ur *UserRepositoryImpl) GetUserByUsernameDB(ctx context.Context, username string) (user *entities.User, err error) {
+	return ur.getUserByField(ctx, "username", username)
+}
+
+func (ur *UserRepositoryImpl) GetUserByPhoneNumberDB(ctx context.Context, phoneNumber string) (user *entities.User, err error) {
+	return ur.getUserByField(ctx, "phone_number", phoneNumber)
+}
+
+func (ur *UserRepositoryImpl) getUserByField(ctx context.Context, field, value string) (res *entities.User, err error) {
+	query := fmt.Sprintf("%s WHERE usr.%s = $1", getUserQuery, field)
+
+	return scanUser(ur.Conn(ctx).QueryRowContext(ctx, query, value))
+}
+
+func (ur *UserRepositoryImpl) UpdateAvatarKeyDB(ctx context.Context, id int64, avatarKey *string) (err error) {
+	stmt, err := ur.Conn(ctx).PrepareContext(ctx, updateAvatarKeyQuery)
+	if err != nil {
+		return fmt.Errorf(database.ErrFailedPrepareStatement, err)
+	}
+
+	defer ur.Database.CloseStatement(stmt, &err)
+
+	updatedAt := time.Now()
+	_, err = stmt.ExecContext(
+		ctx,
+		id,
+		avatarKey,
+		updatedAt,
 	)
+	if err != nil {
+		return domainError.ErrFailedUploadAvatar
+	}
 
+	return nil
+}
+
+func (ur *UserRepositoryImpl) GetPreferencesDB(ctx context.Context, id int64) (res []byte, err error) {
+	err = ur.Conn(ctx).QueryRowContext(ctx, getPreferencesQuery, id).Scan(&res)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	} else if err != nil {
-		return nil, err
+		return nil, domainError.ErrFailedGetPreferences
 	}
 
-	return user, nil
+	return res, nil
 }
 
-func (ur *UserRepositoryImpl) GetUserByEmailDB(ctx context.Context, email string) (user *entities.User, err error) {
-	return ur.getUserByField(ctx, "email", email)
-}
+func (ur *UserRepositoryImpl) UpdatePreferencesDB(ctx context.Context, id int64, preferences []byte) (res []byte, err error) {
+	stmt, err := ur.Conn(ctx).PrepareContext(ctx, updatePreferencesQuery)
+	if err != nil {
+		return nil, fmt.Errorf(database.ErrFailedPrepareStatement, err)
+	}
 
-func (ur *UserRepositoryImpl) GetUserByUsernameDB(ctx context.Context, username string) (user *entities.User, err error) {
-	return ur.getUserByField(ctx, "username", username)
+	defer ur.Database.CloseStatement(stmt, &err)
+
+	updatedAt := time.Now()
+	err = stmt.QueryRowContext(
+		ctx,
+		id,
+		string(preferences),
+		updatedAt,
+	).Scan(&res)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, domainError.ErrFailedUpdatePreferences
+	}
+
+	return res, nil
 }
 
-func (ur *UserRepositoryImpl) getUserByField(ctx context.Context, field, value string) (res *entities.User, err error) {
+func scanUser(row *sql.Row) (*entities.User, error) {
 	user := &entities.User{}
 
-	query := fmt.Sprintf("%s WHERE usr.%s = $1", getUserQuery, field)
-
-	err = ur.DB.QueryRowContext(ctx, query, value).Scan(
+	var createdAt time.Time
+	var lastLogin, updatedAt, deletedAt sql.NullTime
+	err := row.Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.FirstName,
 		&user.LastName,
 		&user.PhoneNumber,
+		&user.AvatarKey,
+		&user.IsActive,
+		&user.IsEmailVerified,
+		&user.IsPhoneVerified,
+		&lastLogin,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -70,5 +130,10 @@ func (ur *UserRepositoryImpl) getUserByField(ctx context.Context, field, value s
 		return nil, err
 	}
 
+	user.CreatedAt = &createdAt
+	user.LastLogin = database.TimePtr(lastLogin)
+	user.UpdatedAt = database.TimePtr(updatedAt)
+	user.DeletedAt = database.TimePtr(deletedAt)
+
 	return user, nil
 }
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/winartodev/apollo-be/config"
+	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	domainError "github.com/winartodev/apollo-be/internal/domain/error"
+	"github.com/winartodev/apollo-be/modules/user/domain/service"
+	"github.com/winartodev/apollo-be/modules/user/usecase/dto"
+)
+
+type AvatarUseCase interface {
+	UploadAvatar(ctx context.Context, data []byte) (res *dto.AvatarDto, err error)
+	GetAvatar(avatarKey string) (res *dto.AvatarDto)
+	MaxAvatarSize() int64
+}
+
+type avatarUseCase struct {
+	config        *config.Watcher
+	userService   service.UserService
+	avatarService service.AvatarService
+}
+
+func NewAvatarUseCase(userService service.UserService, avatarService service.AvatarService, configWatcher *config.Watcher) (AvatarUseCase, error) {
+	return &avatarUseCase{
+		config:        configWatcher,
+		userService:   userService,
+		avatarService: avatarService,
+	}, nil
+}
+
+func (au *avatarUseCase) UploadAvatar(ctx context.Context, data []byte) (res *dto.AvatarDto, err error) {
+	if int64(len(data)) > au.MaxAvatarSize() {
+		return nil, domainError.ErrAvatarTooLarge
+	}
+
+	userID, err := infraContext.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := au.userService.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domainError.ErrUserNotFound
+	}
+
+	avatarKey, err := au.avatarService.UploadAvatar(ctx, userID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = au.avatarService.UpdateAvatarKey(ctx, userID, avatarKey)
+	if err != nil {
+		_ = au.avatarService.DeleteAvatar(ctx, avatarKey)
+		return nil, err
+	}
+
+	// The previous files are no longer referenced, failing to remove them only leaves orphans behind
+	_ = au.avatarService.DeleteAvatar(ctx, user.AvatarKey)
+
+	return au.GetAvatar(avatarKey), nil
+}
+
+func (au *avatarUseCase) GetAvatar(avatarKey string) (res *dto.AvatarDto) {
+	urls := au.avatarService.GetAvatarURLs(avatarKey)
+	if urls == nil {
+		return nil
+	}
+
+	return &dto.AvatarDto{
+		Small:  urls.Small,
+		Medium: urls.Medium,
+		Large:  urls.Large,
+	}
+}
+
+func (au *avatarUseCase) MaxAvatarSize() int64 {
+	return au.config.Current().Avatar.GetMaxSize()
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/winartodev/apollo-be/modules/user/delivery/http/dto"
+)
+
+type AvatarDto struct {
+	Small  string
+	Medium string
+	Large  string
+}
+
+func (a *AvatarDto) ToResponse() *dto.AvatarResponse {
+	if a == nil {
+		return nil
+	}
+
+	return &dto.AvatarResponse{
+		Small:  a.Small,
+		Medium: a.Medium,
+		Large:  a.Large,
+	}
+}
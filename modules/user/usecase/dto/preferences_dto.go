@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/winartodev/apollo-be/modules/user/delivery/http/dto"
+)
+
+type PreferencesDto struct {
+	Locale          string
+	Timezone        string
+	MarketingEmails bool
+	OtpChannel      string
+}
+
+func (p *PreferencesDto) ToResponse() *dto.PreferencesResponse {
+	return &dto.PreferencesResponse{
+		Locale:          p.Locale,
+		Timezone:        p.Timezone,
+		MarketingEmails: p.MarketingEmails,
+		OtpChannel:      p.OtpChannel,
+	}
+}
+
+type UpdatePreferencesDto struct {
+	Locale          *string
+	Timezone        *string
+	MarketingEmails *bool
+	OtpChannel      *string
+}
+
+func NewUpdatePreferencesDto(req dto.UpdatePreferencesRequest) UpdatePreferencesDto {
+	return UpdatePreferencesDto{
+		Locale:          req.Locale,
+		Timezone:        req.Timezone,
+		MarketingEmails: req.MarketingEmails,
+		OtpChannel:      req.OtpChannel,
+	}
+}
@@ -13,6 +13,7 @@ type UserDto struct {
 	PhoneNumber     string
 	FirstName       string
 	LastName        string
+	AvatarKey       string
 	IsActive        bool
 	IsEmailVerified bool
 	IsPhoneVerified bool
@@ -33,9 +34,19 @@ func (u *UserDto) ToResponse() *dto.UserResponse {
 		IsActive:        u.IsActive,
 		IsEmailVerified: u.IsEmailVerified,
 		IsPhoneVerified: u.IsPhoneVerified,
-		LastLogin:       u.LastLogin,
-		CreatedAt:       u.CreatedAt,
-		UpdatedAt:       u.UpdatedAt,
-		DeletedAt:       u.DeletedAt,
+		LastLogin:       formatTime(u.LastLogin),
+		CreatedAt:       formatTime(u.CreatedAt),
+		UpdatedAt:       formatTime(u.UpdatedAt),
+		DeletedAt:       formatTime(u.DeletedAt),
 	}
 }
+
+// formatTime returns the timestamp as RFC 3339 in UTC, or nil when it is not set
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+
+	infraContext "github.com/winartodev/apollo-be/infrastructure/context"
+	"github.com/winartodev/apollo-be/modules/user/domain/entities"
+	"github.com/winartodev/apollo-be/modules/user/domain/service"
+	"github.com/winartodev/apollo-be/modules/user/usecase/dto"
+)
+
+// PreferenceUseCase exposes user preferences to the delivery layer and to other modules
+type PreferenceUseCase interface {
+	GetCurrentUserPreferences(ctx context.Context) (res *dto.PreferencesDto, err error)
+	UpdateCurrentUserPreferences(ctx context.Context, data dto.UpdatePreferencesDto) (res *dto.PreferencesDto, err error)
+	GetUserPreferences(ctx context.Context, userID int64) (res *dto.PreferencesDto, err error)
+}
+
+type preferenceUseCase struct {
+	preferenceService service.PreferenceService
+}
+
+func NewPreferenceUseCase(preferenceService service.PreferenceService) (PreferenceUseCase, error) {
+	return &preferenceUseCase{
+		preferenceService: preferenceService,
+	}, nil
+}
+
+func (pu *preferenceUseCase) GetCurrentUserPreferences(ctx context.Context) (res *dto.PreferencesDto, err error) {
+	userID, err := infraContext.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.GetUserPreferences(ctx, userID)
+}
+
+func (pu *preferenceUseCase) UpdateCurrentUserPreferences(ctx context.Context, data dto.UpdatePreferencesDto) (res *dto.PreferencesDto, err error) {
+	userID, err := infraContext.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if data.Locale != nil {
+		updates[entities.PreferenceLocale] = *data.Locale
+	}
+
+	if data.Timezone != nil {
+		updates[entities.PreferenceTimezone] = *data.Timezone
+	}
+
+	if data.MarketingEmails != nil {
+		updates[entities.PreferenceMarketingEmails] = *data.MarketingEmails
+	}
+
+	if data.OtpChannel != nil {
+		updates[entities.PreferenceOtpChannel] = *data.OtpChannel
+	}
+
+	preferences, err := pu.preferenceService.UpdatePreferences(ctx, userID, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	preferencesDto := preferences.ToUseCaseData()
+
+	return &preferencesDto, nil
+}
+
+func (pu *preferenceUseCase) GetUserPreferences(ctx context.Context, userID int64) (res *dto.PreferencesDto, err error) {
+	preferences, err := pu.preferenceService.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferencesDto := preferences.ToUseCaseData()
+
+	return &preferencesDto, nil
+}
@@ -65,6 +65,16 @@ func (uc *userUseCase) CheckUserIfExists(ctx context.Context, data domainEntity.
 		}
 	}
 
+	if data.PhoneNumber != "" {
+		sharedUser, err = uc.userService.IsPhoneNumberExists(ctx, data.PhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+		if sharedUser != nil {
+			return sharedUser, domainError.ErrPhoneNumberAlreadyExists
+		}
+	}
+
 	return nil, nil
 }
 
@@ -7,11 +7,17 @@ import (
 	"database/sql"
 
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/config"
 	"github.com/winartodev/apollo-be/modules/user/delivery/http"
 )
 
 func InitializeUserAPI(
 	db *sql.DB,
+	redis *redis.Client,
+	jwtConfig *config.Jwt,
+	storageConfig *config.Storage,
+	configWatcher *config.Watcher,
 ) (*http.UserHandler, error) {
 	wire.Build(moduleSet)
 	return &http.UserHandler{}, nil
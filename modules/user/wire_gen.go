@@ -9,9 +9,13 @@ package user
 import (
 	"database/sql"
 
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/apollo-be/config"
 	"github.com/winartodev/apollo-be/infrastructure/auth"
 	"github.com/winartodev/apollo-be/infrastructure/database"
 	"github.com/winartodev/apollo-be/infrastructure/middleware"
+	redis2 "github.com/winartodev/apollo-be/infrastructure/redis"
+	"github.com/winartodev/apollo-be/infrastructure/storage"
 	"github.com/winartodev/apollo-be/modules/user/delivery/http"
 	"github.com/winartodev/apollo-be/modules/user/domain/service"
 	"github.com/winartodev/apollo-be/modules/user/repository"
@@ -20,7 +24,7 @@ import (
 
 // Injectors from wire.go:
 
-func InitializeUserAPI(db *sql.DB) (*http.UserHandler, error) {
+func InitializeUserAPI(db *sql.DB, redis3 *redis.Client, jwtConfig *config.Jwt, storageConfig *config.Storage, configWatcher *config.Watcher) (*http.UserHandler, error) {
 	databaseDatabase, err := database.NewDatabase(db)
 	if err != nil {
 		return nil, err
@@ -37,12 +41,38 @@ func InitializeUserAPI(db *sql.DB) (*http.UserHandler, error) {
 	if err != nil {
 		return nil, err
 	}
-	jwt, err := auth.NewJWT()
+	storageStorage, err := storage.NewStorage(storageConfig)
+	if err != nil {
+		return nil, err
+	}
+	avatarService, err := service.NewAvatarService(userRepository, storageStorage)
+	if err != nil {
+		return nil, err
+	}
+	avatarUseCase, err := usecase.NewAvatarUseCase(userService, avatarService, configWatcher)
+	if err != nil {
+		return nil, err
+	}
+	preferenceService, err := service.NewPreferenceService(userRepository)
+	if err != nil {
+		return nil, err
+	}
+	preferenceUseCase, err := usecase.NewPreferenceUseCase(preferenceService)
+	if err != nil {
+		return nil, err
+	}
+	jwt, err := auth.NewJWT(jwtConfig)
 	if err != nil {
 		return nil, err
 	}
 	tokenService := auth.NewJwtTokenService(jwt)
-	middlewareMiddleware := middleware.NewMiddleware(tokenService)
-	userHandler := http.NewUserHandler(userUseCase, middlewareMiddleware)
+	redisRedis, err := redis2.NewRedis(redis3)
+	if err != nil {
+		return nil, err
+	}
+	userStatusService := auth.NewUserStatusService(databaseDatabase, redisRedis)
+	apiKeyService := auth.NewAPIKeyService(databaseDatabase, redisRedis)
+	middlewareMiddleware := middleware.NewMiddleware(tokenService, userStatusService, apiKeyService)
+	userHandler := http.NewUserHandler(userUseCase, avatarUseCase, preferenceUseCase, middlewareMiddleware)
 	return userHandler, nil
 }